@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/calibration"
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// runCalibration implements the "calibration" subcommand, re-estimating caps
+// from the history of limit hits and showing the evidence.
+func runCalibration(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("calibration", flag.ExitOnError)
+	tierFlag := fs.String("tier", "", "Tier whose published limits are compared")
+	jsonFlag := fs.Bool("json", false, "Output JSON")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	if err := setTier(cfg, *tierFlag); err != nil {
+		return err
+	}
+	resolveTier(cfg)
+
+	store, err := syncedHistory(cfg)
+	if err != nil {
+		return err
+	}
+	c := calibration.Estimate(store, claude.DetectAccount(), time.Now())
+	if cfg.History {
+		if err := calibration.Save(historyDir(cfg), c); err != nil {
+			return err
+		}
+	}
+
+	if *jsonFlag {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	}
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	output := newOutput(cfg)
+	fmt.Fprint(stdout, output.RenderCalibration(c, claude.GetTierLimits(cfg.ClaudeTier)))
+	return nil
+}
+
+// newTracker creates the tracker for the configured limits mode, model
+// families, pace schedule and hours mode.
+func newTracker(cfg *config.Config, store *history.Store) *claude.Tracker {
+	t := claude.NewTracker(cfg.ClaudeTier)
+	if cfg.Limits == config.LimitsCalibrated {
+		if limits, ok := calibratedLimits(cfg, store); ok {
+			t = claude.NewTrackerWithLimits(cfg.ClaudeTier, limits)
+		}
+	}
+	t.Models = modelTable(cfg)
+	t.Pace, _ = claude.ParseSchedule(cfg.PaceSchedule) // Validated by loadConfig
+	t.HoursMode = cfg.HoursMode
+	return t
+}
+
+// calibratedLimits returns the tier limits calibrated from recorded limit
+// hits. They are re-estimated from store when available, otherwise the last
+// saved calibration is used; without one the published limits apply. The
+// saved calibration is only rewritten when the limit hits have changed.
+func calibratedLimits(cfg *config.Config, store *history.Store) (claude.TierLimits, bool) {
+	account := claude.DetectAccount()
+	c, err := calibration.Load(historyDir(cfg), account)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: calibration: %v\n", err)
+	}
+	if store != nil {
+		saved := c
+		c = calibration.Estimate(store, account, time.Now())
+		if !c.SameHits(saved) {
+			if err := calibration.Save(historyDir(cfg), c); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: calibration: %v\n", err)
+			}
+		}
+	}
+
+	if c == nil || c.Empty() {
+		fmt.Fprintln(os.Stderr, "Warning: no limit hits recorded yet; using published limits")
+		return claude.TierLimits{}, false
+	}
+	return claude.GetTierLimits(cfg.ClaudeTier).Calibrated(c.CyclePrompts, c.WeeklyHours), true
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/display"
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// runChart implements the "chart" subcommand.
+func runChart(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("chart", flag.ExitOnError)
+	chartFlag := fs.String("chart", "", "Chart to show (hourly, daily; default both)")
+	heightFlag := fs.Int("height", display.DefaultChartHeight, "Chart height in rows")
+	asciiFlag := fs.Bool("ascii", false, "Draw with ASCII instead of Unicode blocks")
+	jsonFlag := fs.Bool("json", false, "Output JSON")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	charts := report.Charts
+	if *chartFlag != "" {
+		chart, err := report.ParseChart(*chartFlag)
+		if err != nil {
+			return err
+		}
+		charts = []report.Chart{chart}
+	}
+
+	store, err := syncedHistory(cfg)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	series := make([]report.Series, len(charts))
+	for i, chart := range charts {
+		series[i] = report.Activity(store, chart, now, modelTable(cfg))
+	}
+
+	if *jsonFlag {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(series)
+	}
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	output := newOutput(cfg)
+	if *asciiFlag {
+		output.ASCII = true
+	}
+	for i, s := range series {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprint(stdout, output.RenderChart(s, *heightFlag))
+	}
+	return nil
+}
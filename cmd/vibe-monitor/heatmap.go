@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// runHeatmap implements the "heatmap" subcommand.
+func runHeatmap(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	periodFlag := fs.String("period", "year", "Calendar span (year, quarter)")
+	metricFlag := fs.String("metric", "hours", "Color days by hours or prompts")
+	asciiFlag := fs.Bool("ascii", false, "Draw with ASCII instead of Unicode blocks")
+	jsonFlag := fs.Bool("json", false, "Output JSON")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	weeks, err := report.HeatmapWeeks(*periodFlag)
+	if err != nil {
+		return err
+	}
+	metric, err := report.ParseMetric(*metricFlag)
+	if err != nil {
+		return err
+	}
+
+	store, err := syncedHistory(cfg)
+	if err != nil {
+		return err
+	}
+	h := report.BuildHeatmap(store, metric, weeks, time.Now())
+
+	if *jsonFlag {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(h)
+	}
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	output := newOutput(cfg)
+	if *asciiFlag {
+		output.ASCII = true
+	}
+	fmt.Fprint(stdout, output.RenderHeatmap(h))
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// runHistory implements the "history" subcommand: stats, sync or compact.
+func runHistory(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-monitor history [stats|sync|compact]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	action := "stats"
+	if fs.NArg() > 0 {
+		action = fs.Arg(0)
+	}
+
+	store, err := openHistory(cfg)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "stats":
+		sessions := store.Sessions()
+		fmt.Printf("Store:    %s\n", store.Dir())
+		fmt.Printf("Sessions: %d\n", len(sessions))
+		fmt.Printf("Hourly:   %d\n", len(store.Hours(time.Time{}, time.Time{})))
+		fmt.Printf("Stale:    %d\n", store.Stale())
+		if len(sessions) > 0 {
+			fmt.Printf("Range:    %s → %s\n",
+				sessions[0].Start.Local().Format("2006-01-02"),
+				sessions[len(sessions)-1].Start.Local().Format("2006-01-02"))
+		}
+	case "sync":
+		sessions, err := claude.LoadSessions()
+		if err != nil {
+			return err
+		}
+		n, err := store.Sync(sessions)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Recorded %d new or updated sessions.\n", n)
+	case "compact":
+		before := store.Stale()
+		if err := store.Compact(retention(cfg), time.Now()); err != nil {
+			return err
+		}
+		fmt.Printf("Compacted %s (%d superseded records removed).\n", store.Dir(), before)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown history action %q", action)
+	}
+	return nil
+}
+
+// openHistory opens the configured history store.
+func openHistory(cfg *config.Config) (*history.Store, error) {
+	return history.Open(historyDir(cfg))
+}
+
+// historyDir returns the configured history directory.
+func historyDir(cfg *config.Config) string {
+	if cfg.HistoryDir != "" {
+		return cfg.HistoryDir
+	}
+	return history.DefaultDir()
+}
+
+// syncedHistory returns the history store updated with the current session
+// files. When history recording is disabled an in-memory store is used.
+func syncedHistory(cfg *config.Config) (*history.Store, error) {
+	sessions, err := claude.LoadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	store := history.NewMemory()
+	if cfg.History {
+		if store, err = openHistory(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := store.Sync(sessions); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// retention converts the configured retention days into a policy.
+func retention(cfg *config.Config) history.Retention {
+	day := 24 * time.Hour
+	return history.Retention{
+		Sessions: time.Duration(cfg.HistoryRetentionDays) * day,
+		Hours:    time.Duration(cfg.HourlyRetentionDays) * day,
+	}
+}
+
+// recordHistory syncs parsed sessions into the history store, compacting it
+// once superseded records outnumber live ones, and returns the store. It
+// returns nil when history is disabled or unavailable; failures are reported
+// but never prevent the usage display.
+func recordHistory(cfg *config.Config, sessions []*claude.SessionData) *history.Store {
+	if !cfg.History {
+		return nil
+	}
+
+	store, err := openHistory(cfg)
+	if err == nil {
+		_, err = store.Sync(sessions)
+	}
+	if err == nil && store.Stale() > store.Len() {
+		err = store.Compact(retention(cfg), time.Now())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: history: %v\n", err)
+		return nil
+	}
+	return store
+}
@@ -4,27 +4,70 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/injaneity/vibe-monitor/internal/claude"
 	"github.com/injaneity/vibe-monitor/internal/config"
 	"github.com/injaneity/vibe-monitor/internal/display"
+	"github.com/injaneity/vibe-monitor/internal/figlet"
+	"github.com/injaneity/vibe-monitor/internal/history"
 )
 
 var (
 	version = "dev"
 )
 
+// outputFormat selects how usage is printed.
+type outputFormat int
+
+const (
+	formatFull outputFormat = iota
+	formatCompact
+	formatJSON
+)
+
+// commands maps subcommand names to their handlers.
+var commands = map[string]func(cfg *config.Config, args []string) error{
+	"calibration": runCalibration,
+	"chart":       runChart,
+	"heatmap":     runHeatmap,
+	"history":     runHistory,
+	"projects":    runProjects,
+	"report":      runReport,
+	"tier":        runTier,
+	"timeline":    runTimeline,
+}
+
 func main() {
-	tierFlag := flag.String("tier", "", "Subscription tier (free, pro, max_5x, max_20x, auto)")
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(loadConfig(), os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	tierFlag := flag.String("tier", "", "Subscription tier (free, pro, max_5x, max_20x, custom, auto)")
 	compactFlag := flag.Bool("compact", false, "Single-line compact format")
+	jsonFlag := flag.Bool("json", false, "Output usage as JSON")
+	modelsFlag := flag.Bool("models", false, "Show usage per model version")
 	noColorFlag := flag.Bool("no-color", false, "Disable colored output")
-	widthFlag := flag.Int("width", 42, "Progress bar width (20-100)")
+	colorFlag := flag.String("color", "", "Color output (auto, always, never)")
+	themeFlag := flag.String("theme", "", "Color theme (claude, high-contrast, solarized, colorblind, monochrome, ...)")
+	layoutFlag := flag.String("layout", "", "Display layout (stacked, side)")
+	widthFlag := flag.Int("width", 0, "Progress bar width (20-100, 0=fit terminal)")
 	refreshFlag := flag.Int("refresh", 0, "Auto-refresh every N seconds (0=disabled)")
+	limitsFlag := flag.String("limits", "", "Limits to measure against (published, calibrated)")
+	hoursFlag := flag.String("hours", "", "Count parallel sessions as summed or union hours")
+	noHistoryFlag := flag.Bool("no-history", false, "Do not record usage to the history store")
 	versionFlag := flag.Bool("version", false, "Print version and exit")
 	flag.Parse()
 
@@ -35,54 +78,89 @@ func main() {
 
 	cfg := loadConfig()
 
-	if *tierFlag != "" {
-		cfg.ClaudeTier = *tierFlag
+	if err := setTier(cfg, *tierFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	if *noColorFlag || os.Getenv("NO_COLOR") != "" {
+	if *noColorFlag {
 		cfg.NoColor = true
 	}
-	if *widthFlag != 42 {
+	if *themeFlag != "" {
+		cfg.Theme = *themeFlag
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *layoutFlag != "" {
+		if _, err := display.ParseLayout(*layoutFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Layout = *layoutFlag
+	}
+	if *widthFlag != 0 {
 		cfg.Width = *widthFlag
 	}
+	if *noHistoryFlag {
+		cfg.History = false
+	}
+	if *modelsFlag {
+		cfg.ShowModels = true
+	}
+	if *limitsFlag != "" {
+		cfg.Limits = *limitsFlag
+	}
+	if err := setHoursMode(cfg, *hoursFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Limits != config.LimitsPublished && cfg.Limits != config.LimitsCalibrated {
+		fmt.Fprintf(os.Stderr, "Error: invalid limits %q (want published or calibrated)\n", cfg.Limits)
+		os.Exit(1)
+	}
 
-	if cfg.ClaudeTier == "" || cfg.ClaudeTier == "auto" {
-		if tier, err := claude.DetectTier(); err == nil && tier != "" {
-			cfg.ClaudeTier = tier
-		} else {
-			cfg.ClaudeTier = "pro"
-		}
+	resolveTier(cfg)
+
+	format := formatFull
+	if *compactFlag {
+		format = formatCompact
+	}
+	if *jsonFlag {
+		format = formatJSON
 	}
 
 	if *refreshFlag > 0 {
-		runWatchMode(cfg, *refreshFlag, *compactFlag)
+		runWatchMode(cfg, *refreshFlag, format)
 	} else {
-		displayOnce(cfg, *compactFlag)
+		displayOnce(cfg, format)
 	}
 }
 
-func runWatchMode(cfg *config.Config, interval int, compact bool) {
+func runWatchMode(cfg *config.Config, interval int, format outputFormat) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	ticker := time.NewTicker(time.Duration(interval) * time.Second)
 	defer ticker.Stop()
 
-	if !compact {
+	fullscreen := format == formatFull
+	if fullscreen {
 		fmt.Print("\033[2J\033[?25l")
 		defer fmt.Print("\033[?25h")
 	}
 
-	displayOnce(cfg, compact)
+	displayOnce(cfg, format)
 
 	for {
 		select {
 		case <-ticker.C:
-			if !compact {
+			if fullscreen {
 				fmt.Print("\033[2J\033[H")
 			}
-			displayOnce(cfg, compact)
+			displayOnce(cfg, format)
 		case <-sigChan:
-			if !compact {
+			if fullscreen {
 				fmt.Print("\033[?25h")
 			}
 			fmt.Println("\nMonitoring stopped.")
@@ -91,15 +169,59 @@ func runWatchMode(cfg *config.Config, interval int, compact bool) {
 	}
 }
 
-func displayOnce(cfg *config.Config, compact bool) {
-	output := display.NewOutput(cfg.NoColor, cfg.Width)
-	tracker := claude.NewTracker(cfg.ClaudeTier)
+func displayOnce(cfg *config.Config, format outputFormat) {
+	output := newOutput(cfg)
+	output.ShowModels = cfg.ShowModels
+	if cfg.HeaderText != "" {
+		output.HeaderText = cfg.HeaderText
+	}
+	if font, err := figlet.Lookup(cfg.HeaderFont); err == nil {
+		output.Font = font
+	}
+	output.HeaderMode, _ = display.ParseHeaderMode(cfg.HeaderColor)
+	if cfg.HeaderGradient != "" {
+		output.HeaderGradient, _ = display.ParseGradient(cfg.HeaderGradient)
+	}
+	output.Layout, _ = display.ParseLayout(cfg.Layout)
+	if cfg.Widgets != "" {
+		custom, _ := customWidgets(cfg)
+		output.Widgets, _ = display.ParseWidgets(cfg.Widgets, custom)
+	}
+	if format == formatFull {
+		output.TermWidth = display.TerminalWidth(os.Stdout)
+		if cfg.Width == 0 && output.TermWidth > 0 {
+			output.Width = display.AutoWidth(output.TermWidth)
+		}
+	}
 
-	usage, err := tracker.Calculate()
+	sessions, err := claude.LoadSessions()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return
 	}
+	store := recordHistory(cfg, sessions)
+
+	usage := newTracker(cfg, store).CalculateFrom(sessions)
+
+	if output.UsesWidget("sparkline") {
+		output.History = store
+		if store == nil {
+			output.History = history.NewMemory()
+			if _, err := output.History.Sync(sessions); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: history: %v\n", err)
+			}
+		}
+	}
+
+	if format == formatJSON {
+		data, err := output.RenderJSON(usage)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Println(data)
+		return
+	}
 
 	if usage.SessionsCount == 0 {
 		fmt.Println("No Claude Code usage data found.")
@@ -107,14 +229,209 @@ func displayOnce(cfg *config.Config, compact bool) {
 		return
 	}
 
-	if compact {
-		fmt.Println(output.RenderCompact(usage))
+	if format == formatCompact {
+		fmt.Fprintln(stdout, output.RenderCompact(usage))
 	} else {
-		fmt.Print(output.Render(usage))
+		fmt.Fprint(stdout, output.Render(usage))
+	}
+}
+
+// stdout receives rendered output, with colors adapted to the terminal.
+var stdout io.Writer = os.Stdout
+
+// setupColor checks the configured theme, applies a --color value (empty
+// keeps the configured mode, or never when colors were disabled), and adapts
+// output to the color depth of the terminal.
+func setupColor(cfg *config.Config, mode string) error {
+	themes, _ := customThemes(cfg)
+	if _, err := display.LookupTheme(cfg.Theme, themes); err != nil {
+		return err
+	}
+
+	if mode != "" {
+		cfg.Color = mode
+	} else if cfg.NoColor {
+		cfg.Color = display.ColorNever
+	}
+	mode, err := display.ParseColorMode(cfg.Color)
+	if err != nil {
+		return err
+	}
+
+	depth := display.ResolveColorDepth(mode, os.Stdout)
+	cfg.NoColor = depth == display.DepthNone
+	stdout = display.NewWriter(os.Stdout, depth)
+	return nil
+}
+
+// setTier applies a --tier value, rejecting unknown tiers. An empty value
+// leaves the configured tier unchanged.
+func setTier(cfg *config.Config, tier string) error {
+	if tier == "" {
+		return nil
+	}
+	if tier != "auto" && !claude.IsKnownTier(tier) {
+		return fmt.Errorf("unknown tier %q (available: %s)", tier, strings.Join(claude.TierNames(), ", "))
 	}
+	cfg.ClaudeTier = tier
+	return nil
 }
 
+// setHoursMode applies an --hours value. An empty value leaves the
+// configured mode unchanged.
+func setHoursMode(cfg *config.Config, mode string) error {
+	if mode == "" {
+		return nil
+	}
+	mode, err := claude.ParseHoursMode(mode)
+	if err != nil {
+		return err
+	}
+	cfg.HoursMode = mode
+	return nil
+}
+
+// resolveTier replaces an empty or "auto" tier with the detected one,
+// warning when the credentials it came from have expired.
+func resolveTier(cfg *config.Config) {
+	if cfg.ClaudeTier != "" && cfg.ClaudeTier != "auto" {
+		return
+	}
+
+	d, err := claude.DetectTierInfo()
+	if err != nil || d.Tier == "" {
+		cfg.ClaudeTier = "pro"
+		return
+	}
+	cfg.ClaudeTier = d.Tier
+	if d.Expired(time.Now()) {
+		fmt.Fprintf(os.Stderr, "Warning: credentials in %s expired %s; detected tier %q may be stale\n",
+			d.Source, d.ExpiresAt.Local().Format("2006-01-02 15:04"), d.Tier)
+	}
+}
+
+// modelTable returns the configured model families ahead of the built-in ones.
+func modelTable(cfg *config.Config) claude.ModelTable {
+	families := make([]claude.ModelFamily, len(cfg.ModelFamilies))
+	for i, f := range cfg.ModelFamilies {
+		families[i] = claude.ModelFamily(f)
+	}
+	return claude.NewModelTable(families)
+}
+
+// newOutput creates a renderer for the configured colors, theme, width and
+// model families.
+func newOutput(cfg *config.Config) *display.Output {
+	output := display.NewOutput(cfg.NoColor, cfg.Width)
+	output.Models = modelTable(cfg)
+	themes, _ := customThemes(cfg)
+	if theme, err := display.LookupTheme(cfg.Theme, themes); err == nil {
+		output.Theme = theme
+	}
+	output.Thresholds, _ = display.ParseThresholds(cfg.Thresholds)
+	return output
+}
+
+// customThemes parses the themes defined in config, by name. Later
+// definitions may build on earlier ones.
+func customThemes(cfg *config.Config) (map[string]display.Theme, error) {
+	themes := make(map[string]display.Theme, len(cfg.Themes))
+	for _, t := range cfg.Themes {
+		theme, err := display.ParseThemeSpec(t.Name, t.Spec, themes)
+		if err != nil {
+			return nil, fmt.Errorf("THEME_%s: %v", strings.ToUpper(t.Name), err)
+		}
+		themes[theme.Name] = theme
+	}
+	return themes, nil
+}
+
+// customWidgets parses the widgets defined in config, by name.
+func customWidgets(cfg *config.Config) (map[string]display.Widget, error) {
+	widgets := make(map[string]display.Widget, len(cfg.WidgetSpecs))
+	for _, w := range cfg.WidgetSpecs {
+		widget, err := display.ParseWidgetSpec(w.Name, w.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("WIDGET_%s: %v", strings.ToUpper(w.Name), err)
+		}
+		widgets[widget.Name] = widget
+	}
+	return widgets, nil
+}
+
+// loadConfig finds the .env configuration and applies environment overrides.
 func loadConfig() *config.Config {
+	cfg := findConfig()
+	if os.Getenv("NO_COLOR") != "" {
+		cfg.NoColor = true
+	}
+
+	models := modelTable(cfg)
+	for _, t := range cfg.Tiers {
+		limits, match, err := claude.ParseTierSpec(t.Name, t.Spec, models)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: config: TIER_%s: %v\n", strings.ToUpper(t.Name), err)
+			os.Exit(1)
+		}
+		claude.RegisterTier(limits, match)
+	}
+
+	if _, err := customThemes(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := display.ParseThresholds(cfg.Thresholds); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: COLOR_THRESHOLDS: %v\n", err)
+		os.Exit(1)
+	}
+
+	if _, err := figlet.Lookup(cfg.HeaderFont); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: HEADER_FONT: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := display.ParseHeaderMode(cfg.HeaderColor); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: HEADER_COLOR: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.HeaderGradient != "" {
+		if _, err := display.ParseGradient(cfg.HeaderGradient); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: config: HEADER_GRADIENT: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if _, err := display.ParseLayout(cfg.Layout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: LAYOUT: %v\n", err)
+		os.Exit(1)
+	}
+	custom, err := customWidgets(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Widgets != "" {
+		if _, err := display.ParseWidgets(cfg.Widgets, custom); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: config: WIDGETS: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if _, err := claude.ParseSchedule(cfg.PaceSchedule); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: PACE_SCHEDULE: %v\n", err)
+		os.Exit(1)
+	}
+
+	mode, err := claude.ParseHoursMode(cfg.HoursMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: config: HOURS_MODE: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.HoursMode = mode
+
+	return cfg
+}
+
+func findConfig() *config.Config {
 	if cfg, err := config.LoadFromWorkingDir(); err == nil && cfg != nil {
 		return cfg
 	}
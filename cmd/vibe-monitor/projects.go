@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/display"
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// runProjects implements the "projects" subcommand.
+func runProjects(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("projects", flag.ExitOnError)
+	sinceFlag := fs.String("since", "this-week", "Start of range (date, 7d, 2w, last-week, ...)")
+	untilFlag := fs.String("until", "", "End of range, inclusive for dates (default now)")
+	sortFlag := fs.String("sort", "hours", "Sort by hours, prompts, tokens, sessions or name")
+	filterFlag := fs.String("filter", "", "Only show projects whose name contains this text")
+	limitFlag := fs.Int("limit", 0, "Show at most N projects (0=all)")
+	jsonFlag := fs.Bool("json", false, "Output JSON")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	from, to, err := report.ParseRange(*sinceFlag, *untilFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	sortBy, err := claude.ParseProjectSort(*sortFlag)
+	if err != nil {
+		return err
+	}
+
+	store, err := syncedHistory(cfg)
+	if err != nil {
+		return err
+	}
+	all := report.Projects(store, from, to, modelTable(cfg))
+
+	// Shares are measured against all projects, before filtering
+	totalHours := 0.0
+	for _, p := range all {
+		totalHours += p.TotalHours()
+	}
+
+	projects := claude.FilterProjects(all, *filterFlag)
+	claude.SortProjects(projects, sortBy)
+	if *limitFlag > 0 && len(projects) > *limitFlag {
+		projects = projects[:*limitFlag]
+	}
+
+	if *jsonFlag {
+		data, err := display.NewOutput(true, cfg.Width).RenderProjectsJSON(projects, totalHours)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, data)
+		return nil
+	}
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	title := fmt.Sprintf("Projects %s → %s (%.1fh total)",
+		from.Format("2006-01-02"), to.Format("2006-01-02"), totalHours)
+	fmt.Fprint(stdout, newOutput(cfg).RenderProjects(title, projects, totalHours))
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// runReport implements the "report" subcommand.
+func runReport(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	sinceFlag := fs.String("since", "7d", "Start of range (date, 7d, 2w, last-week, ...)")
+	untilFlag := fs.String("until", "", "End of range, inclusive for dates (default now)")
+	groupFlag := fs.String("group-by", "day", "Bucket size (day, week, month)")
+	tierFlag := fs.String("tier", "", "Tier whose limits the report is measured against")
+	modelsFlag := fs.Bool("models", false, "Add a per-model-version breakdown")
+	hoursFlag := fs.String("hours", "", "Hours measured against limits (summed, union)")
+	jsonFlag := fs.Bool("json", false, "Output JSON")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	from, to, err := report.ParseRange(*sinceFlag, *untilFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	group, err := report.ParseGroupBy(*groupFlag)
+	if err != nil {
+		return err
+	}
+	if err := setHoursMode(cfg, *hoursFlag); err != nil {
+		return err
+	}
+
+	store, err := syncedHistory(cfg)
+	if err != nil {
+		return err
+	}
+	if err := setTier(cfg, *tierFlag); err != nil {
+		return err
+	}
+	resolveTier(cfg)
+	r := report.Build(store, from, to, group, cfg.ClaudeTier, cfg.HoursMode, modelTable(cfg))
+	if *modelsFlag {
+		r.Models = report.Models(store, from, to, modelTable(cfg))
+	}
+
+	if *jsonFlag {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	output := newOutput(cfg)
+	fmt.Fprint(stdout, output.RenderReport(r))
+	if *modelsFlag {
+		fmt.Fprintln(stdout)
+		fmt.Fprint(stdout, output.RenderModels("Models", r.Models))
+	}
+	return nil
+}
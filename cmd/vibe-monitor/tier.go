@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/config"
+)
+
+// runTier implements the "tier" subcommand, explaining tier detection.
+func runTier(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("tier", flag.ExitOnError)
+	listFlag := fs.Bool("list", false, "List all tiers and their limits")
+	fs.Parse(args)
+
+	if *listFlag {
+		listTiers(modelTable(cfg))
+		return nil
+	}
+
+	fmt.Printf("Configured: %s\n", valueOr(cfg.ClaudeTier, "auto"))
+
+	d, err := claude.DetectTierInfo()
+	if err != nil {
+		fmt.Printf("Detected:   none (%v)\n", err)
+		fmt.Println("Searched:")
+		for _, path := range claude.CredentialsPaths() {
+			fmt.Printf("  %s\n", path)
+		}
+		return nil
+	}
+
+	fmt.Printf("Detected:   %s\n", d.Tier)
+	fmt.Printf("Source:     %s\n", d.Source)
+	fmt.Printf("Field:      %s = %q\n", d.Field, d.Value)
+	if !d.ExpiresAt.IsZero() {
+		status := "valid until"
+		if d.Expired(time.Now()) {
+			status = "EXPIRED at"
+		}
+		fmt.Printf("Token:      %s %s\n", status, d.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	resolveTier(cfg)
+	fmt.Printf("Using:      %s\n", cfg.ClaudeTier)
+	return nil
+}
+
+// valueOr returns v, or def when v is empty.
+func valueOr(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// listTiers prints every defined tier with its limits, naming model families
+// from models.
+func listTiers(models claude.ModelTable) {
+	fmt.Printf("%-12s %-10s %11s %15s %13s  %s\n", "Tier", "From", "5h Prompts", "Weekly Sonnet", "Weekly Opus", "Other")
+	for _, name := range claude.TierNames() {
+		for _, t := range claude.TierVersions(name) {
+			printTierVersion(name, t, models)
+		}
+	}
+}
+
+// printTierVersion prints one dated version of a tier.
+func printTierVersion(name string, t claude.TierLimits, models claude.ModelTable) {
+	from := "—"
+	if !t.EffectiveFrom.IsZero() {
+		from = t.EffectiveFrom.Local().Format("2006-01-02")
+	}
+	opus := "—"
+	if t.HasOpus() {
+		opus = fmt.Sprintf("%g-%gh", t.WeeklyOpusMin, t.WeeklyOpusMax)
+	}
+	var other []string
+	for _, family := range t.ModelLimitFamilies() {
+		limit := t.ModelLimits[family]
+		other = append(other, fmt.Sprintf("%s %g-%gh", models.Display(family), limit.Min, limit.Max))
+	}
+	fmt.Printf("%-12s %-10s %11s %15s %13s  %s\n", name, from,
+		fmt.Sprintf("%d-%d", t.Cycle5hMin, t.Cycle5hMax),
+		fmt.Sprintf("%g-%gh", t.WeeklySonnetMin, t.WeeklySonnetMax),
+		opus, strings.Join(other, ", "))
+}
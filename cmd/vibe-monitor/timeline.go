@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/config"
+	"github.com/injaneity/vibe-monitor/internal/display"
+)
+
+// runTimeline implements the "timeline" subcommand.
+func runTimeline(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("timeline", flag.ExitOnError)
+	widthFlag := fs.Int("width", 0, "Columns for the week (56-168, 0=fit terminal)")
+	asciiFlag := fs.Bool("ascii", false, "Draw with ASCII instead of Unicode blocks")
+	noColorFlag := fs.Bool("no-color", false, "Disable colored output")
+	colorFlag := fs.String("color", "", "Color output (auto, always, never)")
+	fs.Parse(args)
+
+	width := *widthFlag
+	if width != 0 && (width < display.MinTimelineWidth || width > display.MaxTimelineWidth) {
+		return fmt.Errorf("invalid width %d (want %d-%d, or 0 to fit the terminal)",
+			width, display.MinTimelineWidth, display.MaxTimelineWidth)
+	}
+
+	sessions, err := claude.LoadSessions()
+	if err != nil {
+		return err
+	}
+	resolveTier(cfg)
+	store := recordHistory(cfg, sessions)
+	usage := newTracker(cfg, store).CalculateFrom(sessions)
+
+	if *noColorFlag {
+		cfg.NoColor = true
+	}
+	if err := setupColor(cfg, *colorFlag); err != nil {
+		return err
+	}
+	output := newOutput(cfg)
+	if *asciiFlag {
+		output.ASCII = true
+	}
+	if width == 0 {
+		width = display.TimelineWidth(display.TerminalWidth(os.Stdout))
+	}
+	fmt.Fprint(stdout, output.RenderTimeline(sessions, usage, width))
+	return nil
+}
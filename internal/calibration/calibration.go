@@ -0,0 +1,199 @@
+// Package calibration estimates effective usage caps from observed limit hits.
+package calibration
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// File is the calibration file name within the history directory.
+const File = "calibration.json"
+
+// Window lengths of the limits Claude enforces.
+const (
+	cycleWindow  = 5 * time.Hour
+	weeklyWindow = 7 * 24 * time.Hour
+)
+
+// Evidence is the usage observed in a window when its limit was reached.
+type Evidence struct {
+	claude.LimitEvent
+	Window  string  `json:"window"`
+	Prompts int     `json:"prompts"` // Prompts since the window opened
+	Hours   float64 `json:"hours"`   // Sonnet + Opus hours since the window opened
+}
+
+// Calibration holds the caps learned for one account.
+type Calibration struct {
+	Account       string     `json:"account"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CyclePrompts  float64    `json:"cycle_prompts,omitempty"` // Estimated prompts per 5h cycle
+	WeeklyHours   float64    `json:"weekly_hours,omitempty"`  // Estimated Sonnet + Opus hours per week
+	CycleSamples  int        `json:"cycle_samples"`
+	WeeklySamples int        `json:"weekly_samples"`
+	Evidence      []Evidence `json:"evidence"`
+}
+
+// Empty reports whether no cap could be estimated.
+func (c *Calibration) Empty() bool {
+	return c.CyclePrompts == 0 && c.WeeklyHours == 0
+}
+
+// SameHits reports whether c and other were estimated from the same limit
+// hits. A nil calibration has none.
+func (c *Calibration) SameHits(other *Calibration) bool {
+	var a, b []Evidence
+	if c != nil {
+		a = c.Evidence
+	}
+	if other != nil {
+		b = other.Evidence
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].HitAt.Equal(b[i].HitAt) || !a[i].ResetAt.Equal(b[i].ResetAt) {
+			return false
+		}
+	}
+	return true
+}
+
+// Estimate derives caps from every limit hit in the store. Each hit's window
+// is reconstructed from its advertised reset, and the median consumption
+// across hits is taken as the effective cap.
+func Estimate(store *history.Store, account string, now time.Time) *Calibration {
+	c := &Calibration{Account: account, UpdatedAt: now, Evidence: []Evidence{}}
+
+	seen := make(map[int64]bool)
+	var cyclePrompts, weeklyHours []float64
+
+	for _, session := range store.Sessions() {
+		for _, hit := range session.LimitHits {
+			if !hit.Timed() || seen[hit.ResetAt.Unix()] {
+				continue
+			}
+			seen[hit.ResetAt.Unix()] = true
+
+			e := Evidence{LimitEvent: hit, Window: hit.Window()}
+			length := cycleWindow
+			if e.Window == claude.WindowWeekly {
+				length = weeklyWindow
+			}
+			opened := hit.ResetAt.Add(-length)
+			if opened.After(hit.HitAt) {
+				continue
+			}
+
+			e.Prompts, e.Hours = windowUsage(store, opened, hit.HitAt)
+
+			if e.Window == claude.WindowCycle {
+				cyclePrompts = append(cyclePrompts, float64(e.Prompts))
+			} else {
+				weeklyHours = append(weeklyHours, e.Hours)
+			}
+			c.Evidence = append(c.Evidence, e)
+		}
+	}
+
+	sort.Slice(c.Evidence, func(i, j int) bool {
+		return c.Evidence[i].HitAt.Before(c.Evidence[j].HitAt)
+	})
+	c.CyclePrompts = median(cyclePrompts)
+	c.WeeklyHours = median(weeklyHours)
+	c.CycleSamples = len(cyclePrompts)
+	c.WeeklySamples = len(weeklyHours)
+	return c
+}
+
+// windowUsage totals the prompts and active hours of the store's hourly
+// records within [from, to), pro-rating the partial hours at either end.
+func windowUsage(store *history.Store, from, to time.Time) (prompts int, hours float64) {
+	total := 0.0
+	for _, rec := range store.Hours(from, to) {
+		start, end := rec.Hour, rec.Hour.Add(time.Hour)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		fraction := end.Sub(start).Hours()
+		total += float64(rec.Prompts) * fraction
+		hours += rec.ActiveHours * fraction
+	}
+	return int(math.Round(total)), hours
+}
+
+// median returns the median of values, or 0 when there are none.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// Load reads the calibration stored for account in dir. A missing file or
+// account returns nil without error.
+func Load(dir, account string) (*Calibration, error) {
+	all, err := readAll(dir)
+	if err != nil {
+		return nil, err
+	}
+	return all[account], nil
+}
+
+// Save stores c alongside the calibrations of other accounts in dir.
+func Save(dir string, c *Calibration) error {
+	all, err := readAll(dir)
+	if err != nil {
+		return err
+	}
+	all[c.Account] = c
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, File+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, File))
+}
+
+// readAll reads every account's calibration from dir.
+func readAll(dir string) (map[string]*Calibration, error) {
+	all := make(map[string]*Calibration)
+	data, err := os.ReadFile(filepath.Join(dir, File))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return all, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,39 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// DefaultAccount identifies usage when no signed-in account can be found.
+const DefaultAccount = "default"
+
+// DetectAccount returns the signed-in account's UUID from Claude Code's
+// global config, or DefaultAccount if it cannot be determined.
+func DetectAccount() string {
+	var paths []string
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, ".claude.json"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".claude.json"))
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var cfg struct {
+			OAuthAccount struct {
+				AccountUUID string `json:"accountUuid"`
+			} `json:"oauthAccount"`
+		}
+		if json.Unmarshal(data, &cfg) == nil && cfg.OAuthAccount.AccountUUID != "" {
+			return cfg.OAuthAccount.AccountUUID
+		}
+	}
+	return DefaultAccount
+}
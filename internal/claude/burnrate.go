@@ -0,0 +1,70 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import "time"
+
+// minElapsed keeps rates from exploding right after a window opens.
+const minElapsed = 15 * time.Minute
+
+// Projection estimates when a limit runs out at the current pace.
+type Projection struct {
+	Used        float64
+	Limit       float64
+	Rate        float64 // Consumption per hour (cycle) or per day (weekly)
+	Projected   bool    // False when there is no limit or no consumption yet
+	ExhaustIn   time.Duration
+	ExhaustAt   time.Time
+	BeforeReset bool // The cap is hit before the window resets
+}
+
+// BurnRate describes the current pace of consumption.
+type BurnRate struct {
+	PromptsPerHour float64    // Within the current 5h cycle
+	HoursPerDay    float64    // Active hours per day this week
+	TokensPerHour  float64    // Within the current 5h cycle
+	Cycle          Projection // Prompts against the 5h cap
+	Weekly         Projection // Hours against the weekly cap
+}
+
+// BurnRate computes consumption rates and projects when the 5h prompt cap
+// and the weekly hours cap will be exhausted at the current pace.
+func (u *UsageData) BurnRate() BurnRate {
+	now := u.LastUpdated
+
+	cycleElapsed := now.Sub(u.CycleStartTime)
+	if cycleElapsed < minElapsed {
+		cycleElapsed = minElapsed
+	}
+	weekElapsed := now.Sub(u.WeeklyStartTime)
+	if weekElapsed < minElapsed {
+		weekElapsed = minElapsed
+	}
+
+	b := BurnRate{
+		PromptsPerHour: float64(u.CyclePrompts) / cycleElapsed.Hours(),
+		HoursPerDay:    u.TotalWeeklyHours() / (weekElapsed.Hours() / 24),
+		TokensPerHour:  float64(u.CycleTokens.Total()) / cycleElapsed.Hours(),
+	}
+
+	b.Cycle = project(float64(u.CyclePrompts), float64(u.Tier.Cycle5hMax),
+		b.PromptsPerHour, time.Hour, now, u.CycleResetIn)
+	b.Weekly = project(u.TotalWeeklyHours(), u.Tier.GetTotalWeeklyMax(),
+		b.HoursPerDay, 24*time.Hour, now, u.WeeklyResetIn)
+	return b
+}
+
+// project estimates when used reaches limit at rate units per period.
+func project(used, limit, rate float64, period time.Duration, now time.Time, resetIn time.Duration) Projection {
+	p := Projection{Used: used, Limit: limit, Rate: rate}
+	if limit <= 0 || rate <= 0 {
+		return p
+	}
+
+	p.Projected = true
+	if remaining := limit - used; remaining > 0 {
+		p.ExhaustIn = time.Duration(remaining / rate * float64(period))
+	}
+	p.ExhaustAt = now.Add(p.ExhaustIn)
+	p.BeforeReset = p.ExhaustIn < resetIn
+	return p
+}
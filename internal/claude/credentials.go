@@ -0,0 +1,166 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TierDetection describes where an auto-detected tier came from.
+type TierDetection struct {
+	Tier      string    // Normalized tier name, e.g. "max_20x"
+	Value     string    // Raw value found in the credentials file
+	Source    string    // Credentials file path
+	Field     string    // JSON field path, e.g. "claudeAiOauth.rateLimitTier"
+	ExpiresAt time.Time // OAuth token expiry (zero if unknown)
+}
+
+// Expired reports whether the OAuth token had expired at now. Account details
+// in an expired credentials file may be stale.
+func (d *TierDetection) Expired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && now.After(d.ExpiresAt)
+}
+
+// credentialFields lists the account fields read from a credentials object.
+// Token fields are deliberately absent so they are never decoded.
+type credentialFields struct {
+	RateLimitTier         string `json:"rateLimitTier"`
+	RateLimitTierSnake    string `json:"rate_limit_tier"`
+	SubscriptionType      string `json:"subscriptionType"`
+	SubscriptionTypeSnake string `json:"subscription_type"`
+	Tier                  string `json:"tier"`
+	Plan                  string `json:"plan"`
+	ExpiresAt             int64  `json:"expiresAt"`
+}
+
+// credentialsFile covers both the nested OAuth layout written by current
+// Claude Code versions and the older flat layout.
+type credentialsFile struct {
+	credentialFields
+	ClaudeAiOauth *credentialFields `json:"claudeAiOauth"`
+}
+
+// CredentialsPaths returns candidate credentials files in priority order.
+func CredentialsPaths() []string {
+	var paths []string
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, ".credentials.json"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".claude", ".credentials.json"))
+	}
+	return paths
+}
+
+// DetectTier attempts to read tier from Claude credentials file.
+func DetectTier() (string, error) {
+	d, err := DetectTierInfo()
+	if err != nil {
+		return "", err
+	}
+	return d.Tier, nil
+}
+
+// DetectTierInfo reads the subscription tier from the first credentials file
+// that declares one, reporting the file and field used.
+func DetectTierInfo() (*TierDetection, error) {
+	var lastErr error
+	for _, path := range CredentialsPaths() {
+		d, err := readCredentials(path)
+		if err == nil {
+			return d, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no credentials file found")
+	}
+	return nil, lastErr
+}
+
+// readCredentials extracts tier details from a single credentials file. The
+// raw file contents, which include access and refresh tokens, are wiped
+// before returning.
+func readCredentials(path string) (*TierDetection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(data)
+
+	var creds credentialsFile
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	d := &TierDetection{Source: path}
+	if oauth := creds.ClaudeAiOauth; oauth != nil {
+		d.ExpiresAt = epochTime(oauth.ExpiresAt)
+		if field, value := oauth.tierField(); value != "" {
+			d.Field, d.Value = "claudeAiOauth."+field, value
+		}
+	}
+	if d.Value == "" {
+		if field, value := creds.tierField(); value != "" {
+			d.Field, d.Value = field, value
+		}
+	}
+	if d.Value == "" {
+		return nil, fmt.Errorf("no tier found in %s", path)
+	}
+
+	d.Tier = NormalizeTierName(d.Value)
+	return d, nil
+}
+
+// tierField returns the most specific tier field set, and its JSON name.
+// Rate limit tiers distinguish Max 5x from 20x, so they win over plan names.
+func (c *credentialFields) tierField() (string, string) {
+	switch {
+	case c.RateLimitTier != "":
+		return "rateLimitTier", c.RateLimitTier
+	case c.RateLimitTierSnake != "":
+		return "rate_limit_tier", c.RateLimitTierSnake
+	case c.SubscriptionType != "":
+		return "subscriptionType", c.SubscriptionType
+	case c.SubscriptionTypeSnake != "":
+		return "subscription_type", c.SubscriptionTypeSnake
+	case c.Tier != "":
+		return "tier", c.Tier
+	case c.Plan != "":
+		return "plan", c.Plan
+	}
+	return "", ""
+}
+
+// NormalizeTierName maps credential values such as "default_claude_max_20x"
+// or "max" onto tier names. Unrecognized values are returned lowercased.
+func NormalizeTierName(value string) string {
+	v := strings.ToLower(strings.TrimSpace(value))
+	for _, prefix := range []string{"default_claude_", "default_", "claude_"} {
+		v = strings.TrimPrefix(v, prefix)
+	}
+	if _, ok := Tiers[v]; ok {
+		return v
+	}
+	if tier, ok := TierFromRateLimitTier[v]; ok {
+		return tier
+	}
+	return v
+}
+
+// epochTime converts a Unix timestamp in seconds or milliseconds.
+func epochTime(v int64) time.Time {
+	switch {
+	case v <= 0:
+		return time.Time{}
+	case v > 1e12:
+		return time.UnixMilli(v)
+	default:
+		return time.Unix(v, 0)
+	}
+}
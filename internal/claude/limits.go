@@ -0,0 +1,89 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Limit windows a limit-reached event can apply to.
+const (
+	WindowCycle  = "5h"
+	WindowWeekly = "weekly"
+)
+
+// LimitEvent records Claude reporting that a usage limit was reached.
+type LimitEvent struct {
+	HitAt   time.Time `json:"hit_at"`
+	ResetAt time.Time `json:"reset_at"` // Reset time advertised in the message
+}
+
+// Window infers which limit was hit from the advertised reset: a reset on the
+// weekly boundary after the hit, or beyond one 5-hour cycle, belongs to the
+// weekly limit, any other to the cycle limit.
+func (e LimitEvent) Window() string {
+	if e.ResetAt.Equal(WeekStart(e.HitAt.Local()).AddDate(0, 0, 7)) || e.ResetAt.Sub(e.HitAt) > 5*time.Hour {
+		return WindowWeekly
+	}
+	return WindowCycle
+}
+
+// Timed reports whether the event has the time of its hit. Events without
+// one cannot be placed in a window and are ignored.
+func (e LimitEvent) Timed() bool {
+	return !e.HitAt.IsZero()
+}
+
+// limitReachedPattern matches messages such as "Claude AI usage limit reached|1735689600".
+var limitReachedPattern = regexp.MustCompile(`Claude AI usage limit reached\|(\d{9,13})`)
+
+// limitResetTime extracts the advertised reset time from message content.
+func limitResetTime(content interface{}) (time.Time, bool) {
+	for _, text := range contentTexts(content) {
+		m := limitReachedPattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		epoch, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		return epochTime(epoch), true
+	}
+	return time.Time{}, false
+}
+
+// contentTexts returns the text of string content or of each text block.
+func contentTexts(content interface{}) []string {
+	switch c := content.(type) {
+	case string:
+		return []string{c}
+	case []interface{}:
+		var texts []string
+		for _, item := range c {
+			if m, ok := item.(map[string]interface{}); ok {
+				if text, ok := m["text"].(string); ok {
+					texts = append(texts, text)
+				}
+			}
+		}
+		return texts
+	}
+	return nil
+}
+
+// addLimitEvent records an event unless it has no hit time or the same reset
+// was already seen; Claude Code repeats the message on every retry until the
+// limit resets.
+func (s *SessionData) addLimitEvent(hitAt, resetAt time.Time) {
+	if hitAt.IsZero() {
+		return
+	}
+	for _, e := range s.LimitEvents {
+		if e.ResetAt.Equal(resetAt) {
+			return
+		}
+	}
+	s.LimitEvents = append(s.LimitEvents, LimitEvent{HitAt: hitAt, ResetAt: resetAt})
+}
@@ -0,0 +1,43 @@
+package claude
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimitEventWindow(t *testing.T) {
+	// Monday 2026-03-02 starts the week; the next weekly reset is 03-09
+	weekEnd := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		hitAt   time.Time
+		resetAt time.Time
+		want    string
+	}{
+		{"cycle reset", time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local), time.Date(2026, 3, 4, 13, 0, 0, 0, time.Local), WindowCycle},
+		{"weekly reset days away", time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local), weekEnd, WindowWeekly},
+		{"weekly reset within 5h", weekEnd.Add(-2 * time.Hour), weekEnd, WindowWeekly},
+		{"cycle reset late in the week", weekEnd.Add(-4 * time.Hour), weekEnd.Add(-time.Hour), WindowCycle},
+		{"cycle reset past the boundary", weekEnd.Add(-time.Hour), weekEnd.Add(2 * time.Hour), WindowCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := LimitEvent{HitAt: tt.hitAt, ResetAt: tt.resetAt}
+			if got := e.Window(); got != tt.want {
+				t.Errorf("Window() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLimitEventsSkipsUntimed(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	sessions := []*SessionData{{LimitEvents: []LimitEvent{{ResetAt: now.Add(2 * time.Hour)}}}}
+
+	u := &UsageData{}
+	u.applyLimitEvents(sessions, now)
+	if u.LimitReached != nil || u.CycleResetExact || u.WeeklyResetExact {
+		t.Errorf("event without a hit time applied: %+v", u.LimitReached)
+	}
+}
@@ -0,0 +1,234 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Model family identifiers used for limit accounting.
+const (
+	FamilyOpus      = "opus"
+	FamilySonnet    = "sonnet"
+	FamilyHaiku     = "haiku"
+	FamilySynthetic = "synthetic"
+	FamilyOther     = "other"
+)
+
+// UnknownModel is recorded for assistant messages without a model ID.
+const UnknownModel = "unknown"
+
+// ModelFamily maps model IDs containing Pattern to a family.
+type ModelFamily struct {
+	Family  string // Family identifier, e.g. "opus"
+	Pattern string // Case-insensitive substring of the model ID
+	Display string // Display name, e.g. "Opus"
+	Color   string // Hex color, e.g. "#CC5500"
+}
+
+// DefaultModelFamilies is the built-in model table, matched in order.
+var DefaultModelFamilies = []ModelFamily{
+	{Family: FamilyOpus, Pattern: "opus", Display: "Opus", Color: "#CC5500"},
+	{Family: FamilySonnet, Pattern: "sonnet", Display: "Sonnet", Color: "#D97706"},
+	{Family: FamilyHaiku, Pattern: "haiku", Display: "Haiku", Color: "#0EA5E9"},
+	{Family: FamilySynthetic, Pattern: "<synthetic>", Display: "Synthetic", Color: "#9CA3AF"},
+}
+
+// otherModelColor is used for models that match no family.
+const otherModelColor = "#A78BFA"
+
+// ModelTable classifies model IDs by family, matching in order.
+type ModelTable []ModelFamily
+
+// DefaultModels is the built-in table, used when no families are configured.
+var DefaultModels = NewModelTable(nil)
+
+// NewModelTable returns a table with families ahead of the defaults, so
+// configuration can override or extend the built-in table.
+func NewModelTable(families []ModelFamily) ModelTable {
+	return append(append(ModelTable{}, families...), DefaultModelFamilies...)
+}
+
+// orDefault returns t, or the built-in table when t is empty.
+func (t ModelTable) orDefault() ModelTable {
+	if len(t) == 0 {
+		return DefaultModels
+	}
+	return t
+}
+
+// Display returns the display name of a family, or the family itself when
+// it is not in the table.
+func (t ModelTable) Display(family string) string {
+	for _, f := range t.orDefault() {
+		if f.Family == family {
+			return f.Display
+		}
+	}
+	return family
+}
+
+// Known reports whether family is in the table, or is the family of models
+// that match none.
+func (t ModelTable) Known(family string) bool {
+	if family == FamilyOther {
+		return true
+	}
+	for _, f := range t.orDefault() {
+		if f.Family == family {
+			return true
+		}
+	}
+	return false
+}
+
+// Color returns the hex color of a family, or the color for unmatched
+// models when the family is not in the table.
+func (t ModelTable) Color(family string) string {
+	for _, f := range t.orDefault() {
+		if f.Family == family && f.Color != "" {
+			return f.Color
+		}
+	}
+	return otherModelColor
+}
+
+// ModelInfo is the normalized description of a model ID.
+type ModelInfo struct {
+	ID         string `json:"id"`
+	Family     string `json:"family"`
+	Generation string `json:"generation,omitempty"` // e.g. "4.5"
+	Display    string `json:"display"`              // e.g. "Opus 4.5"
+	Color      string `json:"color"`
+}
+
+// versionPart matches the version components of a model ID.
+var versionPart = regexp.MustCompile(`^\d{1,2}$`)
+
+// Normalize classifies a model ID using the family table.
+func (t ModelTable) Normalize(id string) ModelInfo {
+	lower := strings.ToLower(id)
+	for _, f := range t.orDefault() {
+		if f.Pattern == "" || !strings.Contains(lower, strings.ToLower(f.Pattern)) {
+			continue
+		}
+		info := ModelInfo{
+			ID:         id,
+			Family:     f.Family,
+			Generation: modelGeneration(lower),
+			Display:    f.Display,
+			Color:      f.Color,
+		}
+		if info.Generation != "" {
+			info.Display += " " + info.Generation
+		}
+		return info
+	}
+
+	return ModelInfo{ID: id, Family: FamilyOther, Display: id, Color: otherModelColor}
+}
+
+// PrimaryModel returns the model ID with the most responses, or "" when
+// there are none.
+func PrimaryModel(responses map[string]int) string {
+	best, count := "", 0
+	for id, n := range responses {
+		if n > count || (n == count && id < best) {
+			best, count = id, n
+		}
+	}
+	return best
+}
+
+// modelGeneration extracts the version from IDs such as "claude-opus-4-5-20251101"
+// or "claude-3-5-sonnet-20241022", ignoring date suffixes.
+func modelGeneration(id string) string {
+	var parts []string
+	for _, token := range strings.Split(id, "-") {
+		if versionPart.MatchString(token) {
+			parts = append(parts, token)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// ModelUsage holds usage attributed to a single model ID.
+type ModelUsage struct {
+	Model     ModelInfo `json:"model"`
+	Responses int       `json:"responses"`
+	Hours     float64   `json:"hours"` // Session time apportioned by response share
+}
+
+// SortModels orders models by hours, then responses, then display name.
+func SortModels(models []ModelUsage) {
+	sort.SliceStable(models, func(i, j int) bool {
+		a, b := models[i], models[j]
+		if a.Hours != b.Hours {
+			return a.Hours > b.Hours
+		}
+		if a.Responses != b.Responses {
+			return a.Responses > b.Responses
+		}
+		return a.Model.Display < b.Model.Display
+	})
+}
+
+// SplitByModel apportions hours across models by their share of responses.
+func SplitByModel(hours float64, responses map[string]int) map[string]float64 {
+	total := 0
+	for _, n := range responses {
+		total += n
+	}
+	split := make(map[string]float64, len(responses))
+	if total == 0 {
+		return split
+	}
+	for id, n := range responses {
+		split[id] = hours * float64(n) / float64(total)
+	}
+	return split
+}
+
+// SplitByFamily apportions hours across model families by their share of
+// responses.
+func (t ModelTable) SplitByFamily(hours float64, responses map[string]int) map[string]float64 {
+	split := make(map[string]float64)
+	for id, h := range SplitByModel(hours, responses) {
+		split[t.Normalize(id).Family] += h
+	}
+	return split
+}
+
+// WeeklyFamily returns the weekly total a family's hours count toward: Opus
+// counts as Opus and every other family, Haiku included, as Sonnet.
+func WeeklyFamily(family string) string {
+	if family == FamilyOpus {
+		return FamilyOpus
+	}
+	return FamilySonnet
+}
+
+// WeeklyHours sums hours per family into the weekly Sonnet and Opus totals
+// (see WeeklyFamily).
+func WeeklyHours(families map[string]float64) (sonnet, opus float64) {
+	for family, h := range families {
+		if WeeklyFamily(family) == FamilyOpus {
+			opus += h
+		} else {
+			sonnet += h
+		}
+	}
+	return sonnet, opus
+}
+
+// SplitWeeklyHours divides hours between the weekly Sonnet and Opus totals
+// by each model's share of responses; without responses all hours count as
+// Sonnet.
+func (t ModelTable) SplitWeeklyHours(hours float64, responses map[string]int) (sonnet, opus float64) {
+	families := t.SplitByFamily(hours, responses)
+	if len(families) == 0 {
+		return hours, 0
+	}
+	return WeeklyHours(families)
+}
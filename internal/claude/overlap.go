@@ -0,0 +1,112 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Ways of counting weekly hours when sessions run in parallel.
+const (
+	HoursSummed = "summed" // Each session's span counts in full
+	HoursUnion  = "union"  // Time each model family is in use counts once
+)
+
+// ParseHoursMode validates an hours mode, defaulting to summed.
+func ParseHoursMode(s string) (string, error) {
+	switch s {
+	case "", HoursSummed:
+		return HoursSummed, nil
+	case HoursUnion:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid hours mode %q (want summed or union)", s)
+}
+
+// HoursModeOrDefault returns mode when it is union, and summed otherwise.
+func HoursModeOrDefault(mode string) string {
+	if mode == HoursUnion {
+		return HoursUnion
+	}
+	return HoursSummed
+}
+
+// Span is a session's active interval with the share of it attributed to
+// each model family.
+type Span struct {
+	Start, End time.Time
+	Families   map[string]float64 // Shares of the span per family, summing to 1
+}
+
+// NewSpan creates a span split by responses per model like Tracker splits
+// session hours, defaulting to Sonnet when there are no responses.
+func (t ModelTable) NewSpan(start, end time.Time, responses map[string]int) Span {
+	s := Span{Start: start, End: end, Families: t.SplitByFamily(1, responses)}
+	if len(s.Families) == 0 {
+		s.Families = map[string]float64{FamilySonnet: 1}
+	}
+	return s
+}
+
+// Clip limits the span to [from, to), reporting false when nothing remains.
+func (s Span) Clip(from, to time.Time) (Span, bool) {
+	if s.Start.Before(from) {
+		s.Start = from
+	}
+	if s.End.After(to) {
+		s.End = to
+	}
+	return s, s.End.After(s.Start)
+}
+
+// UnionHours returns the hours per family during which at least one span
+// used that family, so parallel sessions on the same family count once while
+// different families each count in full. A moment counts at the largest
+// share any active span gives the family, which is the span's own share when
+// nothing runs beside it.
+func UnionHours(spans []Span) map[string]float64 {
+	type event struct {
+		at    time.Time
+		open  bool
+		index int
+	}
+	byFamily := make(map[string][]event)
+	for i, s := range spans {
+		if !s.End.After(s.Start) {
+			continue
+		}
+		for family, share := range s.Families {
+			if share > 0 {
+				byFamily[family] = append(byFamily[family], event{s.Start, true, i}, event{s.End, false, i})
+			}
+		}
+	}
+
+	hours := make(map[string]float64, len(byFamily))
+	for family, events := range byFamily {
+		sort.Slice(events, func(i, j int) bool {
+			if !events[i].at.Equal(events[j].at) {
+				return events[i].at.Before(events[j].at)
+			}
+			return !events[i].open && events[j].open // Close before opening
+		})
+
+		active := make(map[int]float64)
+		for i, e := range events {
+			if i > 0 && len(active) > 0 {
+				share := 0.0
+				for _, v := range active {
+					share = max(share, v)
+				}
+				hours[family] += e.at.Sub(events[i-1].at).Hours() * share
+			}
+			if e.open {
+				active[e.index] = spans[e.index].Families[family]
+			} else {
+				delete(active, e.index)
+			}
+		}
+	}
+	return hours
+}
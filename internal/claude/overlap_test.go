@@ -0,0 +1,48 @@
+package claude
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUnionHours(t *testing.T) {
+	at := func(h float64) time.Time {
+		return time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC).Add(time.Duration(h * float64(time.Hour)))
+	}
+	opus := map[string]int{"claude-opus-4-5": 1}
+	sonnet := map[string]int{"claude-sonnet-4-5": 1}
+	mixed := map[string]int{"claude-opus-4-5": 1, "claude-sonnet-4-5": 3}
+
+	tests := []struct {
+		name  string
+		spans []Span
+		want  map[string]float64
+	}{
+		{"different families in parallel", []Span{DefaultModels.NewSpan(at(0), at(1), opus), DefaultModels.NewSpan(at(0), at(1), sonnet)},
+			map[string]float64{FamilyOpus: 1, FamilySonnet: 1}},
+		{"same family in parallel", []Span{DefaultModels.NewSpan(at(0), at(2), sonnet), DefaultModels.NewSpan(at(1), at(3), sonnet)},
+			map[string]float64{FamilySonnet: 3}},
+		{"back to back", []Span{DefaultModels.NewSpan(at(0), at(1), opus), DefaultModels.NewSpan(at(1), at(2), opus)},
+			map[string]float64{FamilyOpus: 2}},
+		{"mixed session alone", []Span{DefaultModels.NewSpan(at(0), at(2), mixed)},
+			map[string]float64{FamilyOpus: 0.5, FamilySonnet: 1.5}},
+		{"mixed beside opus", []Span{DefaultModels.NewSpan(at(0), at(2), mixed), DefaultModels.NewSpan(at(0), at(1), opus)},
+			map[string]float64{FamilyOpus: 1.25, FamilySonnet: 1.5}},
+		{"no responses count as sonnet", []Span{DefaultModels.NewSpan(at(0), at(1), nil)},
+			map[string]float64{FamilySonnet: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnionHours(tt.spans)
+			if len(got) != len(tt.want) {
+				t.Fatalf("UnionHours = %v, want %v", got, tt.want)
+			}
+			for family, want := range tt.want {
+				if math.Abs(got[family]-want) > 1e-9 {
+					t.Errorf("UnionHours[%s] = %v, want %v", family, got[family], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule weights each weekday's share of the weekly budget, indexed by
+// time.Weekday. Days with zero weight are expected to see no usage.
+type Schedule [7]float64
+
+// EvenSchedule spreads the weekly budget evenly across all seven days.
+var EvenSchedule = Schedule{1, 1, 1, 1, 1, 1, 1}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseSchedule parses a comma-separated list of days, day ranges and
+// optional weights, such as "mon-fri" or "mon-fri,sat=0.5". Days that are not
+// listed get no share of the budget. An empty spec is the even schedule.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(strings.ToLower(spec))
+	if spec == "" {
+		return EvenSchedule, nil
+	}
+
+	var s Schedule
+	for _, part := range strings.Split(spec, ",") {
+		days, weightStr, hasWeight := strings.Cut(strings.TrimSpace(part), "=")
+		weight := 1.0
+		if hasWeight {
+			w, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil || w < 0 {
+				return Schedule{}, fmt.Errorf("invalid weight %q for %s", weightStr, days)
+			}
+			weight = w
+		}
+
+		first, last, isRange := strings.Cut(strings.TrimSpace(days), "-")
+		from, ok := weekdays[strings.TrimSpace(first)]
+		if !ok {
+			return Schedule{}, fmt.Errorf("unknown day %q", first)
+		}
+		to := from
+		if isRange {
+			if to, ok = weekdays[strings.TrimSpace(last)]; !ok {
+				return Schedule{}, fmt.Errorf("unknown day %q", last)
+			}
+		}
+		for d := from; ; d = (d + 1) % 7 {
+			s[d] = weight
+			if d == to {
+				break
+			}
+		}
+	}
+
+	if s.total() == 0 {
+		return Schedule{}, fmt.Errorf("schedule %q has no working days", spec)
+	}
+	return s, nil
+}
+
+// total returns the sum of all day weights.
+func (s Schedule) total() float64 {
+	sum := 0.0
+	for _, w := range s {
+		sum += w
+	}
+	return sum
+}
+
+// Elapsed returns the fraction of the weekly budget that the schedule
+// expects to be used between weekStart and now, counting partial days. The
+// zero schedule is the even schedule.
+func (s Schedule) Elapsed(weekStart, now time.Time) float64 {
+	if s.total() <= 0 {
+		s = EvenSchedule
+	}
+	total := s.total()
+
+	used := 0.0
+	for d := 0; d < 7; d++ {
+		dayStart := weekStart.AddDate(0, 0, d)
+		dayEnd := weekStart.AddDate(0, 0, d+1)
+		if !now.After(dayStart) {
+			break
+		}
+		portion := 1.0
+		if now.Before(dayEnd) {
+			portion = now.Sub(dayStart).Seconds() / dayEnd.Sub(dayStart).Seconds()
+		}
+		used += s[dayStart.Weekday()] * portion
+	}
+	return used / total
+}
+
+// PaceHours returns the weekly hours an on-pace week would have used by now.
+func (u *UsageData) PaceHours() float64 {
+	return u.WeeklyPace * u.Tier.GetTotalWeeklyMax()
+}
@@ -4,8 +4,10 @@ package claude
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -19,19 +21,66 @@ type SessionData struct {
 	PromptCount     int
 	SonnetResponses int
 	OpusResponses   int
-	Project         string
+	Project         string         // Repository root or working directory (see ResolveProject)
+	ProjectDir      string         // Claude's encoded project directory name
+	Cwd             string         // Working directory of the first message
+	Models          map[string]int // Responses per model ID
+	Tokens          TokenUsage
+	LimitEvents     []LimitEvent  // Usage limit messages, in file order
+	Hourly          []HourlyUsage // Activity bucketed by clock hour (UTC), oldest first
+}
+
+// TokenUsage counts the tokens reported for assistant responses.
+type TokenUsage struct {
+	Input         int64 `json:"input,omitempty"`
+	Output        int64 `json:"output,omitempty"`
+	CacheCreation int64 `json:"cache_creation,omitempty"`
+	CacheRead     int64 `json:"cache_read,omitempty"`
+}
+
+// Total returns the sum of all token kinds.
+func (t TokenUsage) Total() int64 {
+	return t.Input + t.Output + t.CacheCreation + t.CacheRead
+}
+
+// Add accumulates other into t.
+func (t *TokenUsage) Add(other TokenUsage) {
+	t.Input += other.Input
+	t.Output += other.Output
+	t.CacheCreation += other.CacheCreation
+	t.CacheRead += other.CacheRead
+}
+
+// HourlyUsage holds the activity of a session within a single clock hour.
+type HourlyUsage struct {
+	Hour            time.Time // Start of the hour (UTC)
+	Prompts         int
+	SonnetResponses int
+	OpusResponses   int
+	ActiveHours     float64        // Portion of the session span falling within this hour
+	Models          map[string]int // Responses per model ID
+	Tokens          TokenUsage
 }
 
 // Message represents a single message from the JSONL file.
 type Message struct {
-	Type      string `json:"type"`
-	Timestamp string `json:"timestamp"`
-	IsMeta    bool   `json:"isMeta"`
-	UserType  string `json:"userType"`
+	Type      string      `json:"type"`
+	Timestamp string      `json:"timestamp"`
+	IsMeta    bool        `json:"isMeta"`
+	UserType  string      `json:"userType"`
+	Cwd       string      `json:"cwd"`
+	Content   interface{} `json:"content"` // Top-level content of system messages
 	Message   struct {
+		ID      string      `json:"id"`
 		Role    string      `json:"role"`
 		Model   string      `json:"model"`
 		Content interface{} `json:"content"`
+		Usage   *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
 	} `json:"message"`
 }
 
@@ -44,11 +93,14 @@ func ParseJSONLFile(path string) (*SessionData, error) {
 	defer file.Close()
 
 	session := &SessionData{
-		SessionID: filepath.Base(path),
-		Project:   filepath.Base(filepath.Dir(path)),
+		SessionID:  filepath.Base(path),
+		ProjectDir: filepath.Base(filepath.Dir(path)),
+		Models:     make(map[string]int),
 	}
 
 	var timestamps []time.Time
+	hourly := make(map[int64]*HourlyUsage)
+	seenMessages := make(map[string]bool) // Responses are split across lines sharing one message ID
 	scanner := bufio.NewScanner(file)
 
 	// Increase buffer size for long lines
@@ -66,10 +118,29 @@ func ParseJSONLFile(path string) (*SessionData, error) {
 			continue
 		}
 
+		if session.Cwd == "" && msg.Cwd != "" {
+			session.Cwd = msg.Cwd
+		}
+
 		// Parse timestamp
+		var bucket *HourlyUsage
+		var ts time.Time
 		if msg.Timestamp != "" {
-			if ts, err := parseTimestamp(msg.Timestamp); err == nil {
+			if t, err := parseTimestamp(msg.Timestamp); err == nil && !t.IsZero() {
+				ts = t
 				timestamps = append(timestamps, ts)
+				bucket = hourBucket(hourly, ts)
+			}
+		}
+
+		// Record usage limit messages
+		if msg.Type == "assistant" || msg.Type == "system" {
+			resetAt, ok := limitResetTime(msg.Message.Content)
+			if !ok {
+				resetAt, ok = limitResetTime(msg.Content)
+			}
+			if ok {
+				session.addLimitEvent(ts, resetAt)
 			}
 		}
 
@@ -77,20 +148,57 @@ func ParseJSONLFile(path string) (*SessionData, error) {
 		if msg.Type == "user" && msg.Message.Role == "user" && !msg.IsMeta && msg.UserType == "external" {
 			if !isCommandMessage(msg.Message.Content) {
 				session.PromptCount++
+				if bucket != nil {
+					bucket.Prompts++
+				}
 			}
 		}
 
 		// Count model responses
 		if msg.Type == "assistant" {
-			model := strings.ToLower(msg.Message.Model)
-			if strings.Contains(model, "opus") {
+			model := msg.Message.Model
+			if model == "" {
+				model = UnknownModel
+			}
+			session.Models[model]++
+			if bucket != nil {
+				bucket.Models[model]++
+			}
+
+			// The legacy per-family counts use the built-in table; reports
+			// classify Models with the configured one
+			switch DefaultModels.Normalize(model).Family {
+			case FamilyOpus:
 				session.OpusResponses++
-			} else if strings.Contains(model, "sonnet") {
+				if bucket != nil {
+					bucket.OpusResponses++
+				}
+			case FamilySonnet:
 				session.SonnetResponses++
+				if bucket != nil {
+					bucket.SonnetResponses++
+				}
+			}
+
+			// Count tokens once per API message
+			if u := msg.Message.Usage; u != nil && (msg.Message.ID == "" || !seenMessages[msg.Message.ID]) {
+				seenMessages[msg.Message.ID] = true
+				tokens := TokenUsage{
+					Input:         u.InputTokens,
+					Output:        u.OutputTokens,
+					CacheCreation: u.CacheCreationInputTokens,
+					CacheRead:     u.CacheReadInputTokens,
+				}
+				session.Tokens.Add(tokens)
+				if bucket != nil {
+					bucket.Tokens.Add(tokens)
+				}
 			}
 		}
 	}
 
+	session.Project = ResolveProject(session.Cwd, session.ProjectDir)
+
 	// Calculate session duration
 	if len(timestamps) > 0 {
 		session.StartTime = timestamps[0]
@@ -104,11 +212,49 @@ func ParseJSONLFile(path string) (*SessionData, error) {
 			}
 		}
 		session.DurationHours = session.EndTime.Sub(session.StartTime).Hours()
+		session.Hourly = spreadHourly(hourly, session.StartTime, session.EndTime)
 	}
 
 	return session, scanner.Err()
 }
 
+// hourBucket returns the hourly bucket containing ts, creating it if needed.
+func hourBucket(hourly map[int64]*HourlyUsage, ts time.Time) *HourlyUsage {
+	hour := ts.UTC().Truncate(time.Hour)
+	bucket, ok := hourly[hour.Unix()]
+	if !ok {
+		bucket = &HourlyUsage{Hour: hour, Models: make(map[string]int)}
+		hourly[hour.Unix()] = bucket
+	}
+	return bucket
+}
+
+// spreadHourly distributes the session span across hourly buckets and
+// returns them in chronological order.
+func spreadHourly(hourly map[int64]*HourlyUsage, start, end time.Time) []HourlyUsage {
+	for hour := start.UTC().Truncate(time.Hour); hour.Before(end); hour = hour.Add(time.Hour) {
+		from, to := hour, hour.Add(time.Hour)
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
+		}
+		if to.After(from) {
+			hourBucket(hourly, hour).ActiveHours += to.Sub(from).Hours()
+		}
+	}
+
+	buckets := make([]HourlyUsage, 0, len(hourly))
+	for _, b := range hourly {
+		buckets = append(buckets, *b)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Hour.Before(buckets[j].Hour)
+	})
+	return buckets
+}
+
 // parseTimestamp parses an ISO timestamp string.
 func parseTimestamp(ts string) (time.Time, error) {
 	// Try multiple formats
@@ -182,3 +328,28 @@ func FindAllSessions() ([]string, error) {
 
 	return sessions, err
 }
+
+// LoadSessions parses all session files, skipping unreadable files and
+// sessions with no real activity.
+func LoadSessions() ([]*SessionData, error) {
+	sessionPaths, err := FindAllSessions()
+	if err != nil {
+		return nil, fmt.Errorf("finding sessions: %w", err)
+	}
+
+	var sessions []*SessionData
+	for _, path := range sessionPaths {
+		session, err := ParseJSONLFile(path)
+		if err != nil || session == nil {
+			continue
+		}
+
+		// Skip sessions with no real activity
+		if session.DurationHours <= 0 && session.PromptCount == 0 {
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
@@ -0,0 +1,89 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProjectUsage holds usage attributed to a single project.
+type ProjectUsage struct {
+	Project     string     `json:"project"` // Repository root or directory path
+	Name        string     `json:"name"`    // Short display name
+	Prompts     int        `json:"prompts"`
+	SonnetHours float64    `json:"sonnet_hours"`
+	OpusHours   float64    `json:"opus_hours"`
+	Tokens      TokenUsage `json:"tokens"`
+	Sessions    int        `json:"sessions"`
+}
+
+// TotalHours returns combined Sonnet + Opus hours.
+func (p ProjectUsage) TotalHours() float64 {
+	return p.SonnetHours + p.OpusHours
+}
+
+// ProjectSort selects the ordering of a project list.
+type ProjectSort string
+
+// Supported project orderings. Numeric orderings are descending.
+const (
+	SortByHours    ProjectSort = "hours"
+	SortByPrompts  ProjectSort = "prompts"
+	SortByTokens   ProjectSort = "tokens"
+	SortBySessions ProjectSort = "sessions"
+	SortByName     ProjectSort = "name"
+)
+
+// ParseProjectSort validates a --sort value.
+func ParseProjectSort(s string) (ProjectSort, error) {
+	switch by := ProjectSort(s); by {
+	case SortByHours, SortByPrompts, SortByTokens, SortBySessions, SortByName:
+		return by, nil
+	}
+	return "", fmt.Errorf("invalid sort %q (want hours, prompts, tokens, sessions or name)", s)
+}
+
+// SortProjects orders projects in place, breaking ties by name.
+func SortProjects(projects []ProjectUsage, by ProjectSort) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		switch by {
+		case SortByPrompts:
+			if a.Prompts != b.Prompts {
+				return a.Prompts > b.Prompts
+			}
+		case SortByTokens:
+			if a.Tokens.Total() != b.Tokens.Total() {
+				return a.Tokens.Total() > b.Tokens.Total()
+			}
+		case SortBySessions:
+			if a.Sessions != b.Sessions {
+				return a.Sessions > b.Sessions
+			}
+		case SortByHours:
+			if a.TotalHours() != b.TotalHours() {
+				return a.TotalHours() > b.TotalHours()
+			}
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Project < b.Project
+	})
+}
+
+// FilterProjects returns the projects whose name or path contains substr, ignoring case.
+func FilterProjects(projects []ProjectUsage, substr string) []ProjectUsage {
+	if substr == "" {
+		return projects
+	}
+	substr = strings.ToLower(substr)
+	var filtered []ProjectUsage
+	for _, p := range projects {
+		if strings.Contains(strings.ToLower(p.Name), substr) || strings.Contains(strings.ToLower(p.Project), substr) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
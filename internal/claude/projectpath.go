@@ -0,0 +1,244 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// projectCache memoizes ResolveProject, which touches the filesystem.
+var projectCache = struct {
+	sync.Mutex
+	roots map[string]string
+}{roots: make(map[string]string)}
+
+// ResolveProject returns the project a session belongs to: the git repository
+// root containing cwd, or cwd itself outside a repository. When cwd is unknown
+// the Claude project directory name is decoded instead, and only looked up in
+// git when the decoded directory exists.
+func ResolveProject(cwd, dirName string) string {
+	path := filepath.Clean(cwd)
+	lookup := true
+	if cwd == "" {
+		path = DecodeProjectDir(dirName)
+		_, err := os.Stat(path)
+		lookup = err == nil
+	}
+
+	projectCache.Lock()
+	defer projectCache.Unlock()
+	if root, ok := projectCache.roots[path]; ok {
+		return root
+	}
+
+	root := ""
+	if lookup {
+		root = gitRoot(path)
+	}
+	if root == "" {
+		root = stripWorktree(path)
+	}
+	projectCache.roots[path] = root
+	return root
+}
+
+// CanonicalProject normalizes a stored project value, resolving raw Claude
+// directory names recorded before paths were decoded.
+func CanonicalProject(project string) string {
+	if isEncodedDir(project) {
+		return ResolveProject("", project)
+	}
+	return project
+}
+
+// isEncodedDir reports whether name looks like a dash-encoded project directory.
+func isEncodedDir(name string) bool {
+	return strings.HasPrefix(name, "-") && !strings.ContainsRune(name, filepath.Separator)
+}
+
+// DecodeProjectDir converts a Claude project directory name such as
+// "-home-alice-src-my-app" back into a path. Claude replaces path separators
+// and dots with dashes, so existing directories are matched greedily to
+// recover dashes within names; unmatched tails fall back to one component
+// per dash.
+func DecodeProjectDir(name string) string {
+	if !isEncodedDir(name) {
+		return name
+	}
+	parts := strings.Split(name[1:], "-")
+	if path, ok := decodeExisting(string(filepath.Separator), parts); ok {
+		return path
+	}
+	return string(filepath.Separator) + filepath.Join(parts...)
+}
+
+// decodeExisting matches encoded parts against directories below dir,
+// preferring the longest component that exists.
+func decodeExisting(dir string, parts []string) (string, bool) {
+	if len(parts) == 0 {
+		return dir, true
+	}
+
+	for n := len(parts); n >= 1; n-- {
+		var candidates []string
+		if parts[0] == "" && n >= 2 {
+			// "--claude" encodes "/.claude"
+			for _, c := range joinings(parts[1:n]) {
+				candidates = append(candidates, "."+c)
+			}
+		} else if parts[0] != "" {
+			candidates = joinings(parts[:n])
+		}
+
+		for _, c := range candidates {
+			path := filepath.Join(dir, c)
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				if full, ok := decodeExisting(path, parts[n:]); ok {
+					return full, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// maxJoinParts bounds the dash/dot combinations tried for one path component.
+const maxJoinParts = 6
+
+// joinings returns the ways parts can be joined with dashes or dots.
+func joinings(parts []string) []string {
+	if len(parts) > maxJoinParts {
+		return []string{strings.Join(parts, "-")}
+	}
+	results := []string{parts[0]}
+	for _, part := range parts[1:] {
+		next := make([]string, 0, len(results)*2)
+		for _, r := range results {
+			next = append(next, r+"-"+part, r+"."+part)
+		}
+		results = next
+	}
+	return results
+}
+
+// gitRoot returns the repository root containing dir, mapping linked
+// worktrees to their main repository. It returns "" outside a repository.
+// The walk stops below the home directory, so a dotfiles repository in $HOME
+// does not swallow every project beneath it.
+func gitRoot(dir string) string {
+	home, _ := os.UserHomeDir()
+	for d := dir; ; {
+		if home != "" && d == filepath.Clean(home) {
+			return ""
+		}
+		dotGit := filepath.Join(d, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return d
+			}
+			if main := worktreeMain(d, dotGit); main != "" {
+				return main
+			}
+			return d
+		}
+
+		parent := filepath.Dir(d)
+		if parent == d {
+			return ""
+		}
+		d = parent
+	}
+}
+
+// worktreeMain reads a .git file and returns the main repository root when it
+// points into another repository's worktrees directory.
+func worktreeMain(dir, dotGit string) string {
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return ""
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+
+	marker := string(filepath.Separator) + filepath.Join(".git", "worktrees") + string(filepath.Separator)
+	if idx := strings.Index(gitdir, marker); idx > 0 {
+		return gitdir[:idx]
+	}
+	return ""
+}
+
+// stripWorktree maps conventional worktree locations of directories that no
+// longer exist back to their repository.
+func stripWorktree(path string) string {
+	for _, marker := range []string{"/.claude/worktrees/", "/.worktrees/"} {
+		marker = filepath.FromSlash(marker)
+		if idx := strings.Index(path, marker); idx > 0 {
+			return path[:idx]
+		}
+	}
+	return path
+}
+
+// ShortProjectNames returns a short display name for each project path: the
+// base name, extended with parent directories until names are unique.
+func ShortProjectNames(projects []string) map[string]string {
+	names := make(map[string]string, len(projects))
+	depth := make(map[string]int, len(projects))
+	for _, p := range projects {
+		depth[p] = 1
+	}
+
+	for round := 0; round < 8; round++ {
+		seen := make(map[string][]string)
+		for _, p := range projects {
+			names[p] = tailPath(DecodeProjectDir(p), depth[p])
+			seen[names[p]] = append(seen[names[p]], p)
+		}
+
+		clash := false
+		for _, group := range seen {
+			if len(group) > 1 {
+				clash = true
+				for _, p := range group {
+					depth[p]++
+				}
+			}
+		}
+		if !clash {
+			break
+		}
+	}
+	return names
+}
+
+// tailPath returns the last n elements of path, or "/" for the root.
+func tailPath(path string, n int) string {
+	parts := strings.Split(strings.Trim(filepath.ToSlash(path), "/"), "/")
+	if len(parts) == 1 && parts[0] == "" {
+		return "/"
+	}
+	if n > len(parts) {
+		n = len(parts)
+	}
+	return strings.Join(parts[len(parts)-n:], "/")
+}
+
+// NameProjects fills in the display name of each project.
+func NameProjects(projects []ProjectUsage) {
+	paths := make([]string, len(projects))
+	for i, p := range projects {
+		paths[i] = p.Project
+	}
+	names := ShortProjectNames(paths)
+	for i := range projects {
+		projects[i].Name = names[projects[i].Project]
+	}
+}
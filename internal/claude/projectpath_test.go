@@ -0,0 +1,125 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// encodeDir encodes a path the way Claude names its project directories.
+func encodeDir(path string) string {
+	return strings.NewReplacer(string(filepath.Separator), "-", ".", "-").Replace(path)
+}
+
+// mkdirs creates each directory below root.
+func mkdirs(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, d := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDecodeProjectDir(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(root, "-.") {
+		t.Skipf("temp dir %s is itself ambiguous when encoded", root)
+	}
+	mkdirs(t, root,
+		"src/my-app",
+		"src/my.lib",
+		"src/my/app-old",
+		".claude/worktrees/fix-bug",
+		"a-b-c-d-e-f-g",
+		"z.b-c-d-e-f-g",
+	)
+
+	tests := []struct {
+		name string
+		path string // Below root; the encoded form is decoded
+		want string // Below root
+	}{
+		{"plain", "src", "src"},
+		{"dash in name", "src/my-app", "src/my-app"},
+		{"dot in name", "src/my.lib", "src/my.lib"},
+		{"backtracks past a dashed sibling", "src/my/app-old", "src/my/app-old"},
+		{"hidden directory", ".claude/worktrees/fix-bug", ".claude/worktrees/fix-bug"},
+		{"many dashes", "a-b-c-d-e-f-g", "a-b-c-d-e-f-g"},
+		{"beyond maxJoinParts only dashes", "z.b-c-d-e-f-g", "z/b/c/d/e/f/g"},
+		{"missing tail", "src/gone-app", "src/gone/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeDir(filepath.Join(root, tt.path))
+			want := filepath.Join(root, tt.want)
+			if got := DecodeProjectDir(encoded); got != want {
+				t.Errorf("DecodeProjectDir(%q) = %q, want %q", encoded, got, want)
+			}
+		})
+	}
+}
+
+func TestGitRoot(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := filepath.Join(root, "home")
+	t.Setenv("HOME", home)
+
+	mkdirs(t, root,
+		"home/.git", // Dotfiles repository
+		"home/notes/drafts",
+		"home/repo/.git/worktrees/feature",
+		"home/repo/pkg",
+		"home/feature/cmd",
+		"home/rel",
+		"outside/proj/.git",
+		"outside/proj/sub",
+	)
+	write := func(path, content string) {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("home/feature/.git", "gitdir: "+filepath.Join(home, "repo/.git/worktrees/feature")+"\n")
+	write("home/rel/.git", "gitdir: ../repo/.git/worktrees/feature\n")
+
+	tests := []struct {
+		name string
+		dir  string // Below root
+		want string // Below root, or "" outside a repository
+	}{
+		{"repository root", "home/repo", "home/repo"},
+		{"subdirectory", "home/repo/pkg", "home/repo"},
+		{"worktree", "home/feature/cmd", "home/repo"},
+		{"relative worktree gitdir", "home/rel", "home/repo"},
+		{"stops at home", "home/notes/drafts", ""},
+		{"outside home", "outside/proj/sub", "outside/proj"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := ""
+			if tt.want != "" {
+				want = filepath.Join(root, tt.want)
+			}
+			if got := gitRoot(filepath.Join(root, tt.dir)); got != want {
+				t.Errorf("gitRoot(%s) = %q, want %q", tt.dir, got, want)
+			}
+		})
+	}
+
+	// Outside a repository a session belongs to its own directory
+	notes := filepath.Join(home, "notes/drafts")
+	if got := ResolveProject(notes, ""); got != notes {
+		t.Errorf("ResolveProject(%s) = %q, want the directory itself", notes, got)
+	}
+	if got, want := ResolveProject("", encodeDir(filepath.Join(home, "feature/cmd"))), filepath.Join(home, "repo"); got != want {
+		t.Errorf("ResolveProject of encoded worktree = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,11 @@
 // Package claude provides usage tracking for Claude Code by parsing local session files.
 package claude
 
+import (
+	"sort"
+	"time"
+)
+
 // TierLimits defines the usage limits for a subscription tier.
 type TierLimits struct {
 	Tier            string  // Tier identifier
@@ -10,12 +15,25 @@ type TierLimits struct {
 	WeeklySonnetMax float64 // Maximum weekly Sonnet hours
 	WeeklyOpusMin   float64 // Minimum weekly Opus hours (0 if not available)
 	WeeklyOpusMax   float64 // Maximum weekly Opus hours (0 if not available)
+
+	// ModelLimits caps the weekly hours of other families, keyed by family.
+	// Those hours also count toward the weekly Sonnet hours (see WeeklyFamily),
+	// so each cap applies within the Sonnet cap rather than beside it.
+	ModelLimits map[string]ModelLimit
+
+	EffectiveFrom time.Time // When these limits took effect (zero for always)
 }
 
-// Predefined tier limits based on Claude's actual limits.
+// WeeklyLimitsStart is when Claude introduced weekly usage limits; the
+// built-in tier limits apply from then on.
+var WeeklyLimitsStart = time.Date(2025, 8, 28, 0, 0, 0, 0, time.UTC)
+
+// Predefined tier limits based on Claude's actual limits. Each entry holds the
+// limits currently in force; dated versions are kept in tierHistory.
 var Tiers = map[string]TierLimits{
 	"free": {
 		Tier:            "free",
+		EffectiveFrom:   WeeklyLimitsStart,
 		Cycle5hMin:      10,
 		Cycle5hMax:      40,
 		WeeklySonnetMin: 40,
@@ -23,6 +41,7 @@ var Tiers = map[string]TierLimits{
 	},
 	"pro": {
 		Tier:            "pro",
+		EffectiveFrom:   WeeklyLimitsStart,
 		Cycle5hMin:      10,
 		Cycle5hMax:      40,
 		WeeklySonnetMin: 40,
@@ -30,6 +49,7 @@ var Tiers = map[string]TierLimits{
 	},
 	"max_5x": {
 		Tier:            "max_5x",
+		EffectiveFrom:   WeeklyLimitsStart,
 		Cycle5hMin:      50,
 		Cycle5hMax:      200,
 		WeeklySonnetMin: 140,
@@ -39,6 +59,7 @@ var Tiers = map[string]TierLimits{
 	},
 	"max_20x": {
 		Tier:            "max_20x",
+		EffectiveFrom:   WeeklyLimitsStart,
 		Cycle5hMin:      200,
 		Cycle5hMax:      800,
 		WeeklySonnetMin: 240,
@@ -52,6 +73,7 @@ var Tiers = map[string]TierLimits{
 var TierFromRateLimitTier = map[string]string{
 	"free":       "free",
 	"pro":        "pro",
+	"max":        "max_5x", // Plan name without a multiplier
 	"max5":       "max_5x",
 	"max5x":      "max_5x",
 	"max_5x":     "max_5x",
@@ -62,18 +84,75 @@ var TierFromRateLimitTier = map[string]string{
 	"enterprise": "max_20x", // Enterprise defaults to max_20x level
 }
 
-// GetTierLimits returns the limits for a given tier, defaulting to "pro" if unknown.
+// IsKnownTier reports whether name is a defined tier or a mapped alias.
+func IsKnownTier(name string) bool {
+	if _, ok := Tiers[name]; ok {
+		return true
+	}
+	_, ok := TierFromRateLimitTier[name]
+	return ok
+}
+
+// TierNames returns the defined tier names in sorted order.
+func TierNames() []string {
+	names := make([]string, 0, len(Tiers))
+	for name := range Tiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tierHistory holds every dated version of tiers that have more than one,
+// oldest first. Tiers without an entry have only their Tiers version.
+var tierHistory = map[string][]TierLimits{}
+
+// GetTierLimits returns the limits in force now for a given tier, defaulting
+// to "pro" if unknown. A tier whose versions all take effect later resolves
+// to its earliest version.
 func GetTierLimits(tier string) TierLimits {
-	if limits, ok := Tiers[tier]; ok {
-		return limits
+	limits, _ := GetTierLimitsAt(tier, time.Now())
+	return limits
+}
+
+// GetTierLimitsAt returns the limits that were in force for a tier at the
+// given time, defaulting to "pro" if unknown. It reports false for times
+// before the earliest version, when no limit was known to apply, returning
+// that earliest version for callers that need a starting point.
+func GetTierLimitsAt(tier string, at time.Time) (TierLimits, bool) {
+	if _, ok := Tiers[tier]; !ok {
+		// Check if it's a rate_limit_tier mapping
+		if mappedTier, ok := TierFromRateLimitTier[tier]; ok {
+			tier = mappedTier
+		}
+	}
+	if _, ok := Tiers[tier]; !ok {
+		tier = "pro"
 	}
-	// Check if it's a rate_limit_tier mapping
-	if mappedTier, ok := TierFromRateLimitTier[tier]; ok {
-		if limits, ok := Tiers[mappedTier]; ok {
-			return limits
+
+	versions := TierVersions(tier)
+	limits := versions[0]
+	if limits.EffectiveFrom.After(at) {
+		return limits, false
+	}
+	for _, v := range versions[1:] {
+		if v.EffectiveFrom.After(at) {
+			break
 		}
+		limits = v
+	}
+	return limits, true
+}
+
+// TierVersions returns every dated version of a tier, oldest first.
+func TierVersions(tier string) []TierLimits {
+	if versions, ok := tierHistory[tier]; ok {
+		return versions
+	}
+	if limits, ok := Tiers[tier]; ok {
+		return []TierLimits{limits}
 	}
-	return Tiers["pro"]
+	return nil
 }
 
 // HasOpus returns true if the tier includes Opus access.
@@ -85,3 +164,38 @@ func (t TierLimits) HasOpus() bool {
 func (t TierLimits) GetTotalWeeklyMax() float64 {
 	return t.WeeklySonnetMax + t.WeeklyOpusMax
 }
+
+// ModelLimitFamilies returns the families with per-model caps, sorted.
+func (t TierLimits) ModelLimitFamilies() []string {
+	families := make([]string, 0, len(t.ModelLimits))
+	for family := range t.ModelLimits {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return families
+}
+
+// ModelLimit is a weekly hours cap for an additional model family.
+type ModelLimit struct {
+	Min float64
+	Max float64
+}
+
+// Calibrated returns a copy of the limits with observed caps applied. A
+// positive prompts value replaces the 5h cycle range; a positive hours value
+// rescales the weekly Sonnet and Opus maximums so they total hours while
+// keeping their proportions.
+func (t TierLimits) Calibrated(prompts, hours float64) TierLimits {
+	if prompts > 0 {
+		t.Cycle5hMin = int(prompts)
+		t.Cycle5hMax = int(prompts)
+	}
+	if total := t.GetTotalWeeklyMax(); hours > 0 && total > 0 {
+		scale := hours / total
+		t.WeeklySonnetMax *= scale
+		t.WeeklyOpusMax *= scale
+		t.WeeklySonnetMin = min(t.WeeklySonnetMin, t.WeeklySonnetMax)
+		t.WeeklyOpusMin = min(t.WeeklyOpusMin, t.WeeklyOpusMax)
+	}
+	return t
+}
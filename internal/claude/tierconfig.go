@@ -0,0 +1,236 @@
+// Package claude provides usage tracking for Claude Code by parsing local session files.
+package claude
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTierSpec builds tier limits from a configuration spec of comma
+// separated key=value pairs, for example:
+//
+//	base=max_20x,cycle_5h_max=600,weekly_opus_max=60,weekly_haiku_max=100,match=enterprise|team
+//
+// Unset limits are inherited from base, which defaults to the existing tier
+// of the same name when there is one. The from key (a 2006-01-02 date) adds a
+// version effective from that date; without it the spec replaces the base
+// version it was derived from. Keys of the form weekly_<family>_min
+// and weekly_<family>_max set caps for additional model families. The match
+// key lists credential values that should select this tier. Families must be
+// in models.
+func ParseTierSpec(name, spec string, models ModelTable) (TierLimits, []string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "auto" {
+		return TierLimits{}, nil, fmt.Errorf("invalid tier name %q", name)
+	}
+
+	fields := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return TierLimits{}, nil, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+
+	base := name
+	if b, ok := fields["base"]; ok {
+		base = strings.ToLower(b)
+		delete(fields, "base")
+	}
+	at := time.Now()
+	var from time.Time
+	if f, ok := fields["from"]; ok {
+		t, err := time.ParseInLocation("2006-01-02", f, time.Local)
+		if err != nil {
+			return TierLimits{}, nil, fmt.Errorf("from: expected a 2006-01-02 date, got %q", f)
+		}
+		from, at = t, t
+		delete(fields, "from")
+	}
+
+	var limits TierLimits
+	if _, ok := Tiers[base]; ok {
+		// A version dated before the base's first inherits that first one
+		limits, _ = GetTierLimitsAt(base, at)
+	} else if base != name {
+		return TierLimits{}, nil, fmt.Errorf("unknown base tier %q", base)
+	}
+	if !from.IsZero() {
+		limits.EffectiveFrom = from
+	}
+	limits.Tier = name
+	limits.ModelLimits = copyModelLimits(limits.ModelLimits)
+
+	var match []string
+	if m, ok := fields["match"]; ok {
+		for _, v := range strings.Split(m, "|") {
+			if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+				match = append(match, v)
+			}
+		}
+		delete(fields, "match")
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := setTierField(&limits, key, fields[key], models); err != nil {
+			return TierLimits{}, nil, err
+		}
+	}
+
+	if err := limits.Validate(); err != nil {
+		return TierLimits{}, nil, err
+	}
+	return limits, match, nil
+}
+
+// setTierField assigns a single spec key.
+func setTierField(t *TierLimits, key, value string, models ModelTable) error {
+	switch key {
+	case "cycle_5h_min", "cycle_5h_max":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: expected a whole number, got %q", key, value)
+		}
+		if key == "cycle_5h_min" {
+			t.Cycle5hMin = n
+		} else {
+			t.Cycle5hMax = n
+		}
+		return nil
+	}
+
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%s: expected hours, got %q", key, value)
+	}
+
+	switch key {
+	case "weekly_sonnet_min":
+		t.WeeklySonnetMin = hours
+	case "weekly_sonnet_max":
+		t.WeeklySonnetMax = hours
+	case "weekly_opus_min":
+		t.WeeklyOpusMin = hours
+	case "weekly_opus_max":
+		t.WeeklyOpusMax = hours
+	default:
+		family, bound, ok := modelLimitKey(key)
+		if !ok {
+			return fmt.Errorf("unknown tier field %q", key)
+		}
+		if !models.Known(family) {
+			return fmt.Errorf("%s: unknown model family %q", key, family)
+		}
+		if t.ModelLimits == nil {
+			t.ModelLimits = make(map[string]ModelLimit)
+		}
+		limit := t.ModelLimits[family]
+		if bound == "min" {
+			limit.Min = hours
+		} else {
+			limit.Max = hours
+		}
+		t.ModelLimits[family] = limit
+	}
+	return nil
+}
+
+// modelLimitKey splits "weekly_<family>_<min|max>".
+func modelLimitKey(key string) (family, bound string, ok bool) {
+	rest, ok := strings.CutPrefix(key, "weekly_")
+	if !ok {
+		return "", "", false
+	}
+	idx := strings.LastIndex(rest, "_")
+	if idx <= 0 {
+		return "", "", false
+	}
+	family, bound = rest[:idx], rest[idx+1:]
+	if bound != "min" && bound != "max" {
+		return "", "", false
+	}
+	return family, bound, true
+}
+
+// Validate checks that limits are non-negative and each minimum does not
+// exceed its maximum.
+func (t TierLimits) Validate() error {
+	if t.Cycle5hMin < 0 || t.Cycle5hMax < 0 {
+		return fmt.Errorf("cycle limits must not be negative")
+	}
+	if t.Cycle5hMin > t.Cycle5hMax {
+		return fmt.Errorf("cycle_5h_min (%d) exceeds cycle_5h_max (%d)", t.Cycle5hMin, t.Cycle5hMax)
+	}
+
+	ranges := []struct {
+		name     string
+		min, max float64
+	}{
+		{"weekly_sonnet", t.WeeklySonnetMin, t.WeeklySonnetMax},
+		{"weekly_opus", t.WeeklyOpusMin, t.WeeklyOpusMax},
+	}
+	for family, limit := range t.ModelLimits {
+		ranges = append(ranges, struct {
+			name     string
+			min, max float64
+		}{"weekly_" + family, limit.Min, limit.Max})
+	}
+
+	for _, r := range ranges {
+		if r.min < 0 || r.max < 0 {
+			return fmt.Errorf("%s limits must not be negative", r.name)
+		}
+		if r.min > r.max {
+			return fmt.Errorf("%s_min (%g) exceeds %s_max (%g)", r.name, r.min, r.name, r.max)
+		}
+	}
+	return nil
+}
+
+// RegisterTier adds a tier version, replacing any version of the same tier
+// with the same effective date, and maps the given credential values to it.
+func RegisterTier(limits TierLimits, match []string) {
+	var versions []TierLimits
+	for _, v := range TierVersions(limits.Tier) {
+		if !v.EffectiveFrom.Equal(limits.EffectiveFrom) {
+			versions = append(versions, v)
+		}
+	}
+	versions = append(versions, limits)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].EffectiveFrom.Before(versions[j].EffectiveFrom)
+	})
+
+	tierHistory[limits.Tier] = versions
+	Tiers[limits.Tier] = versions[0] // Ensure the tier exists before resolving
+	Tiers[limits.Tier] = GetTierLimits(limits.Tier)
+
+	for _, value := range match {
+		TierFromRateLimitTier[value] = limits.Tier
+	}
+}
+
+// copyModelLimits returns an independent copy of m.
+func copyModelLimits(m map[string]ModelLimit) map[string]ModelLimit {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]ModelLimit, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
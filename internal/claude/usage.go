@@ -2,10 +2,7 @@
 package claude
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 )
 
@@ -13,6 +10,7 @@ import (
 type UsageData struct {
 	// 5-hour cycle stats
 	CyclePrompts   int
+	CycleTokens    TokenUsage
 	CycleStartTime time.Time
 
 	// Weekly stats
@@ -20,24 +18,48 @@ type UsageData struct {
 	WeeklyOpusHours   float64
 	WeeklyPrompts     int
 	WeeklyStartTime   time.Time
-
-	// Reset times
-	CycleResetIn  time.Duration
-	WeeklyResetIn time.Duration
+	WeeklyPace        float64 // Fraction of the weekly budget expected to be used by now
+
+	// Weekly hours counted both ways (see HoursSummed and HoursUnion); the
+	// WeeklySonnetHours and WeeklyOpusHours above follow HoursMode
+	HoursMode         string
+	SummedSonnetHours float64
+	SummedOpusHours   float64
+	UnionSonnetHours  float64
+	UnionOpusHours    float64
+	UnionFamilyHours  map[string]float64 // Union hours of every family
+
+	// Reset times, estimated from cycle and week boundaries unless a
+	// limit-reached message advertised the exact reset
+	CycleResetIn     time.Duration
+	WeeklyResetIn    time.Duration
+	CycleResetExact  bool
+	WeeklyResetExact bool
+	LimitReached     *LimitEvent // Most recent limit hit whose reset is still ahead
 
 	// Tier info
 	Tier     TierLimits
 	TierName string
 
+	// Weekly usage per project and per model version, heaviest first
+	Projects []ProjectUsage
+	Models   []ModelUsage
+
 	// Metadata
 	LastUpdated   time.Time
 	SessionsCount int
+	SessionsToday int // Sessions active since local midnight
 }
 
 // Tracker manages usage calculation.
 type Tracker struct {
 	tier     TierLimits
 	tierName string
+
+	Models ModelTable // Model families; the built-in table when empty
+	Pace   Schedule   // Working days for the weekly pace; even when zero
+
+	HoursMode string // How parallel sessions count (HoursSummed or HoursUnion); summed when empty
 }
 
 // NewTracker creates a tracker with the specified tier.
@@ -48,64 +70,115 @@ func NewTracker(tierName string) *Tracker {
 	}
 }
 
+// NewTrackerWithLimits creates a tracker using explicit limits, such as
+// calibrated ones, instead of the tier's published limits.
+func NewTrackerWithLimits(tierName string, limits TierLimits) *Tracker {
+	return &Tracker{
+		tier:     limits,
+		tierName: tierName,
+	}
+}
+
 // Calculate computes current usage statistics.
 func (t *Tracker) Calculate() (*UsageData, error) {
-	// Time boundaries
-	now := time.Now()
-	weekStart := getWeekStart(now)
-	cycleStart := get5hCycleStart(now)
-
-	// Find all sessions
-	sessionPaths, err := FindAllSessions()
+	sessions, err := LoadSessions()
 	if err != nil {
-		return nil, fmt.Errorf("finding sessions: %w", err)
+		return nil, err
 	}
+	return t.CalculateFrom(sessions), nil
+}
+
+// CalculateFrom computes current usage statistics from already parsed sessions.
+func (t *Tracker) CalculateFrom(sessions []*SessionData) *UsageData {
+	// Time boundaries
+	now := time.Now()
+	weekStart := WeekStart(now)
+	cycleStart := CycleStart(now)
 
 	usage := &UsageData{
 		CycleStartTime:  cycleStart,
 		WeeklyStartTime: weekStart,
+		WeeklyPace:      t.Pace.Elapsed(weekStart, now),
+		HoursMode:       HoursModeOrDefault(t.HoursMode),
 		Tier:            t.tier,
 		TierName:        t.tierName,
 		LastUpdated:     now,
 	}
+	var spans []Span
 
-	// Parse and aggregate sessions
-	for _, path := range sessionPaths {
-		session, err := ParseJSONLFile(path)
-		if err != nil || session == nil {
-			continue
-		}
+	projects := make(map[string]*ProjectUsage)
+	models := make(map[string]*ModelUsage)
 
-		// Skip sessions with no real activity
-		if session.DurationHours <= 0 && session.PromptCount == 0 {
-			continue
-		}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
+	// Aggregate sessions
+	for _, session := range sessions {
 		usage.SessionsCount++
+		if !session.EndTime.Before(today) {
+			usage.SessionsToday++
+		}
 
 		// Check if session is in current 5h cycle
 		if session.StartTime.After(cycleStart) || session.StartTime.Equal(cycleStart) {
 			usage.CyclePrompts += session.PromptCount
 		}
+		for _, h := range session.Hourly {
+			if !h.Hour.Before(cycleStart) {
+				usage.CycleTokens.Add(h.Tokens)
+			}
+		}
 
 		// Check if session is in current week
 		if session.StartTime.After(weekStart) || session.StartTime.Equal(weekStart) {
 			usage.WeeklyPrompts += session.PromptCount
 
 			// Calculate model-specific hours
-			totalResponses := session.SonnetResponses + session.OpusResponses
-			if totalResponses > 0 {
-				sonnetRatio := float64(session.SonnetResponses) / float64(totalResponses)
-				opusRatio := float64(session.OpusResponses) / float64(totalResponses)
-				usage.WeeklySonnetHours += session.DurationHours * sonnetRatio
-				usage.WeeklyOpusHours += session.DurationHours * opusRatio
-			} else {
-				// Default to Sonnet if no model info
-				usage.WeeklySonnetHours += session.DurationHours
+			sonnetHours, opusHours := t.Models.SplitWeeklyHours(session.DurationHours, session.Models)
+			usage.SummedSonnetHours += sonnetHours
+			usage.SummedOpusHours += opusHours
+			spans = append(spans, t.Models.NewSpan(session.StartTime, session.EndTime, session.Models))
+
+			project, ok := projects[session.Project]
+			if !ok {
+				project = &ProjectUsage{Project: session.Project}
+				projects[session.Project] = project
+			}
+			project.Prompts += session.PromptCount
+			project.SonnetHours += sonnetHours
+			project.OpusHours += opusHours
+			project.Tokens.Add(session.Tokens)
+			project.Sessions++
+
+			for id, hours := range SplitByModel(session.DurationHours, session.Models) {
+				m, ok := models[id]
+				if !ok {
+					m = &ModelUsage{Model: t.Models.Normalize(id)}
+					models[id] = m
+				}
+				m.Responses += session.Models[id]
+				m.Hours += hours
 			}
 		}
 	}
 
+	usage.UnionFamilyHours = UnionHours(spans)
+	usage.UnionSonnetHours, usage.UnionOpusHours = WeeklyHours(usage.UnionFamilyHours)
+	usage.WeeklySonnetHours, usage.WeeklyOpusHours = usage.SummedSonnetHours, usage.SummedOpusHours
+	if usage.HoursMode == HoursUnion {
+		usage.WeeklySonnetHours, usage.WeeklyOpusHours = usage.UnionSonnetHours, usage.UnionOpusHours
+	}
+
+	for _, project := range projects {
+		usage.Projects = append(usage.Projects, *project)
+	}
+	NameProjects(usage.Projects)
+	SortProjects(usage.Projects, SortByHours)
+
+	for _, m := range models {
+		usage.Models = append(usage.Models, *m)
+	}
+	SortModels(usage.Models)
+
 	// Calculate reset times
 	usage.CycleResetIn = cycleStart.Add(5 * time.Hour).Sub(now)
 	if usage.CycleResetIn < 0 {
@@ -118,11 +191,47 @@ func (t *Tracker) Calculate() (*UsageData, error) {
 		usage.WeeklyResetIn = 0
 	}
 
-	return usage, nil
+	usage.applyLimitEvents(sessions, now)
+
+	return usage
+}
+
+// applyLimitEvents prefers reset times advertised by limit-reached messages
+// over the estimates while those resets are still in the future.
+func (u *UsageData) applyLimitEvents(sessions []*SessionData, now time.Time) {
+	var cycle, weekly *LimitEvent
+	for _, session := range sessions {
+		for i := range session.LimitEvents {
+			e := &session.LimitEvents[i]
+			if !e.Timed() || !e.ResetAt.After(now) {
+				continue
+			}
+			if e.Window() == WindowCycle {
+				if cycle == nil || e.HitAt.After(cycle.HitAt) {
+					cycle = e
+				}
+			} else if weekly == nil || e.HitAt.After(weekly.HitAt) {
+				weekly = e
+			}
+		}
+	}
+
+	if cycle != nil {
+		u.CycleResetIn = cycle.ResetAt.Sub(now)
+		u.CycleResetExact = true
+		u.LimitReached = cycle
+	}
+	if weekly != nil {
+		u.WeeklyResetIn = weekly.ResetAt.Sub(now)
+		u.WeeklyResetExact = true
+		if u.LimitReached == nil || weekly.HitAt.After(u.LimitReached.HitAt) {
+			u.LimitReached = weekly
+		}
+	}
 }
 
-// getWeekStart returns Monday 00:00:00 of the current week.
-func getWeekStart(now time.Time) time.Time {
+// WeekStart returns Monday 00:00:00 of the week containing now.
+func WeekStart(now time.Time) time.Time {
 	daysSinceMonday := int(now.Weekday()) - 1
 	if daysSinceMonday < 0 {
 		daysSinceMonday = 6 // Sunday
@@ -131,8 +240,8 @@ func getWeekStart(now time.Time) time.Time {
 	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, now.Location())
 }
 
-// get5hCycleStart returns the start of the current 5-hour cycle.
-func get5hCycleStart(now time.Time) time.Time {
+// CycleStart returns the start of the 5-hour cycle containing now.
+func CycleStart(now time.Time) time.Time {
 	// Calculate hours since Unix epoch
 	hoursSinceEpoch := float64(now.Unix()) / 3600
 	cycleNumber := int(hoursSinceEpoch / 5)
@@ -145,6 +254,33 @@ func (u *UsageData) TotalWeeklyHours() float64 {
 	return u.WeeklySonnetHours + u.WeeklyOpusHours
 }
 
+// SummedWeeklyHours returns combined Sonnet + Opus hours with each session
+// counted in full, which the per-project and per-model hours add up to.
+func (u *UsageData) SummedWeeklyHours() float64 {
+	return u.SummedSonnetHours + u.SummedOpusHours
+}
+
+// OverlapHours returns the weekly hours during which sessions ran in
+// parallel: the summed hours beyond the union.
+func (u *UsageData) OverlapHours() float64 {
+	return max(0, u.SummedSonnetHours+u.SummedOpusHours-u.UnionSonnetHours-u.UnionOpusHours)
+}
+
+// FamilyHours returns weekly hours attributed to models of a family,
+// counted like the weekly totals according to HoursMode.
+func (u *UsageData) FamilyHours(family string) float64 {
+	if u.HoursMode == HoursUnion {
+		return u.UnionFamilyHours[family]
+	}
+	hours := 0.0
+	for _, m := range u.Models {
+		if m.Model.Family == family {
+			hours += m.Hours
+		}
+	}
+	return hours
+}
+
 // WeeklyPercentage returns usage as percentage of weekly limit.
 func (u *UsageData) WeeklyPercentage() float64 {
 	total := u.TotalWeeklyHours()
@@ -169,41 +305,3 @@ func FormatResetTime(d time.Duration) string {
 	}
 	return fmt.Sprintf("%dm", minutes)
 }
-
-// DetectTier attempts to read tier from Claude credentials file.
-func DetectTier() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-
-	credPath := filepath.Join(home, ".claude", ".credentials.json")
-	data, err := os.ReadFile(credPath)
-	if err != nil {
-		return "", err
-	}
-
-	var creds struct {
-		RateLimitTier string `json:"rate_limit_tier"`
-		// Also check other possible field names
-		Tier string `json:"tier"`
-		Plan string `json:"plan"`
-	}
-
-	if err := json.Unmarshal(data, &creds); err != nil {
-		return "", err
-	}
-
-	// Try different field names
-	if creds.RateLimitTier != "" {
-		return creds.RateLimitTier, nil
-	}
-	if creds.Tier != "" {
-		return creds.Tier, nil
-	}
-	if creds.Plan != "" {
-		return creds.Plan, nil
-	}
-
-	return "", fmt.Errorf("no tier found in credentials")
-}
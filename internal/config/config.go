@@ -12,15 +12,77 @@ import (
 type Config struct {
 	ClaudeTier string // Claude subscription tier (free, pro, max_5x, max_20x)
 	NoColor    bool   // Disable colors in output
-	Width      int    // Progress bar width
+	Color      string // Color mode (auto, always, never)
+	Theme      string // Color theme name
+	Thresholds string // Usage color breakpoints as "medium,high" percentages
+	HeaderText string // Title shown in the ASCII art header
+	HeaderFont string // Embedded FIGlet font name or path to a .flf file
+
+	HeaderColor    string // Header color mode (solid, horizontal, vertical, glyph, usage)
+	HeaderGradient string // Comma-separated #RRGGBB gradient stops
+	Width          int    // Progress bar width (0 fits the terminal)
+	Layout         string // Full display layout (stacked, side)
+	Widgets        string // Comma-separated widgets of the stacked layout (empty for the default)
+	ShowModels     bool   // Show per-model-version usage
+	Limits         string // Limits to measure against (published, calibrated)
+
+	PaceSchedule string // Working days weighting the weekly pace marker, e.g. "mon-fri"
+	HoursMode    string // How parallel sessions count towards weekly hours (summed, union)
+
+	History              bool   // Record usage aggregates to the history store
+	HistoryDir           string // History store directory (empty for the XDG default)
+	HistoryRetentionDays int    // Days to keep session records (0 keeps forever)
+	HourlyRetentionDays  int    // Days to keep hourly records (0 keeps forever)
+
+	ModelFamilies []ModelFamily // Extra model families, matched before the built-in table
+	Tiers         []TierSpec    // Custom or overridden tiers, in file order
+	Themes        []ThemeSpec   // Custom or overridden themes, in file order
+	WidgetSpecs   []WidgetSpec  // Custom widgets and widget options, in file order
+}
+
+// TierSpec is a raw TIER_<NAME> entry; the value is validated when applied.
+type TierSpec struct {
+	Name string // Lowercased <NAME>
+	Spec string // Comma-separated key=value limits
+}
+
+// ThemeSpec is a raw THEME_<NAME> entry; the value is validated when applied.
+type ThemeSpec struct {
+	Name string // Lowercased <NAME>, with underscores as dashes
+	Spec string // Comma-separated role=#RRGGBB colors
+}
+
+// WidgetSpec is a raw WIDGET_<NAME> entry; the value is validated when applied.
+type WidgetSpec struct {
+	Name string // Lowercased <NAME>, with underscores as dashes
+	Spec string // Comma-separated key=value options
+}
+
+// ModelFamily is a user-defined model family from a MODEL_FAMILY_<NAME> entry.
+type ModelFamily struct {
+	Family  string // Lowercased <NAME>
+	Pattern string // Substring of the model ID
+	Display string // Display name
+	Color   string // Hex color
 }
 
+// Limit modes for Config.Limits.
+const (
+	LimitsPublished  = "published"
+	LimitsCalibrated = "calibrated"
+)
+
 // DefaultConfig returns default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		ClaudeTier: "pro",
+		ClaudeTier: "auto",
 		NoColor:    false,
-		Width:      42,
+		Color:      "auto",
+		Theme:      "claude",
+		Limits:     LimitsPublished,
+
+		History:             true,
+		HourlyRetentionDays: 400,
 	}
 }
 
@@ -59,28 +121,137 @@ func Load(dir string) (*Config, error) {
 		// Remove quotes if present
 		value = strings.Trim(value, `"'`)
 
+		// TIER_<NAME>=base=max_20x,weekly_opus_max=60,...
+		if name, ok := strings.CutPrefix(key, "TIER_"); ok && name != "" {
+			cfg.Tiers = append(cfg.Tiers, TierSpec{Name: strings.ToLower(name), Spec: value})
+			continue
+		}
+
+		// THEME_<NAME>=base=solarized,header=#FF8800,...
+		if name, ok := strings.CutPrefix(key, "THEME_"); ok && name != "" {
+			name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+			cfg.Themes = append(cfg.Themes, ThemeSpec{Name: name, Spec: value})
+			continue
+		}
+
+		// WIDGET_<NAME>=type=text,color=dim,text=Ship it
+		if name, ok := strings.CutPrefix(key, "WIDGET_"); ok && name != "" {
+			name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+			cfg.WidgetSpecs = append(cfg.WidgetSpecs, WidgetSpec{Name: name, Spec: value})
+			continue
+		}
+
+		// MODEL_FAMILY_<NAME>=pattern,Display Name,#RRGGBB
+		if name, ok := strings.CutPrefix(key, "MODEL_FAMILY_"); ok {
+			if family, ok := parseModelFamily(name, value); ok {
+				cfg.ModelFamilies = append(cfg.ModelFamilies, family)
+			}
+			continue
+		}
+
 		switch key {
 		case "CLAUDE_TIER":
 			cfg.ClaudeTier = value
 		case "NO_COLOR":
 			cfg.NoColor = value == "1" || strings.ToLower(value) == "true"
+		case "COLOR":
+			cfg.Color = strings.ToLower(value)
+		case "THEME":
+			cfg.Theme = strings.ToLower(value)
+		case "COLOR_THRESHOLDS":
+			cfg.Thresholds = value
+		case "HEADER_TEXT":
+			cfg.HeaderText = value
+		case "HEADER_FONT":
+			cfg.HeaderFont = expandHome(value)
+		case "HEADER_COLOR":
+			cfg.HeaderColor = strings.ToLower(value)
+		case "HEADER_GRADIENT":
+			cfg.HeaderGradient = value
+		case "LAYOUT":
+			cfg.Layout = strings.ToLower(value)
+		case "WIDGETS":
+			cfg.Widgets = value
 		case "PROGRESS_WIDTH":
-			// Simple integer parsing
-			width := 0
-			for _, c := range value {
-				if c >= '0' && c <= '9' {
-					width = width*10 + int(c-'0')
-				}
-			}
+			width := parseInt(value)
 			if width >= 20 && width <= 100 {
 				cfg.Width = width
 			}
+		case "LIMITS":
+			cfg.Limits = strings.ToLower(value)
+		case "PACE_SCHEDULE":
+			cfg.PaceSchedule = value
+		case "HOURS_MODE":
+			cfg.HoursMode = strings.ToLower(value)
+		case "SHOW_MODELS":
+			cfg.ShowModels = parseBool(value, cfg.ShowModels)
+		case "HISTORY":
+			cfg.History = parseBool(value, cfg.History)
+		case "HISTORY_DIR":
+			cfg.HistoryDir = expandHome(value)
+		case "HISTORY_RETENTION_DAYS":
+			cfg.HistoryRetentionDays = parseInt(value)
+		case "HISTORY_HOURLY_RETENTION_DAYS":
+			cfg.HourlyRetentionDays = parseInt(value)
 		}
 	}
 
 	return cfg, scanner.Err()
 }
 
+// parseModelFamily parses a "pattern,Display,#color" model family value.
+// Display defaults to the family name and color may be omitted.
+func parseModelFamily(name, value string) (ModelFamily, bool) {
+	fields := strings.Split(value, ",")
+	family := ModelFamily{
+		Family:  strings.ToLower(name),
+		Pattern: strings.TrimSpace(fields[0]),
+		Display: name,
+	}
+	if family.Pattern == "" || family.Family == "" {
+		return ModelFamily{}, false
+	}
+	if len(fields) > 1 && strings.TrimSpace(fields[1]) != "" {
+		family.Display = strings.TrimSpace(fields[1])
+	}
+	if len(fields) > 2 {
+		family.Color = strings.TrimSpace(fields[2])
+	}
+	return family, true
+}
+
+// parseInt parses a non-negative integer, ignoring non-digit characters.
+func parseInt(value string) int {
+	n := 0
+	for _, c := range value {
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+		}
+	}
+	return n
+}
+
+// parseBool parses common boolean spellings, returning def for anything else.
+func parseBool(value string, def bool) bool {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "on":
+		return true
+	case "0", "false", "no", "off":
+		return false
+	}
+	return def
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
 // LoadFromWorkingDir loads config from current working directory.
 func LoadFromWorkingDir() (*Config, error) {
 	dir, err := os.Getwd()
@@ -0,0 +1,63 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/calibration"
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// calibrationRow is the column layout of the evidence table.
+const calibrationRow = "  %-17s %-7s %-17s %8s %8s"
+
+// RenderCalibration shows learned caps next to the published limits, followed
+// by the limit hits they were derived from.
+func (o *Output) RenderCalibration(c *calibration.Calibration, published claude.TierLimits) string {
+	pal := o.palette()
+	var sb strings.Builder
+
+	sb.WriteString(o.color("  Calibration for "+c.Account, Bold+pal.Header))
+	sb.WriteString("\n\n")
+
+	cycle := "no hits yet"
+	if c.CycleSamples > 0 {
+		cycle = fmt.Sprintf("~%.0f prompts (%d hits)", c.CyclePrompts, c.CycleSamples)
+	}
+	weekly := "no hits yet"
+	if c.WeeklySamples > 0 {
+		weekly = fmt.Sprintf("~%.1fh (%d hits)", c.WeeklyHours, c.WeeklySamples)
+	}
+
+	sb.WriteString(o.color(fmt.Sprintf("    5h cycle:  %-24s published %d-%d prompts",
+		cycle, published.Cycle5hMin, published.Cycle5hMax), pal.Dim))
+	sb.WriteString("\n")
+	sb.WriteString(o.color(fmt.Sprintf("    Weekly:    %-24s published %.1fh",
+		weekly, published.GetTotalWeeklyMax()), pal.Dim))
+	sb.WriteString("\n\n")
+
+	if len(c.Evidence) == 0 {
+		sb.WriteString(o.color("  No usage limit messages found in history.", pal.Muted))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	header := fmt.Sprintf(calibrationRow, "Hit at", "Window", "Reset at", "Prompts", "Hours")
+	sb.WriteString(o.color(header, pal.Text))
+	sb.WriteString("\n")
+	sb.WriteString(o.color("  "+strings.Repeat(Horizontal, len(header)-2), pal.Border))
+	sb.WriteString("\n")
+
+	for _, e := range c.Evidence {
+		row := fmt.Sprintf(calibrationRow,
+			e.HitAt.Local().Format("2006-01-02 15:04"),
+			e.Window,
+			e.ResetAt.Local().Format("2006-01-02 15:04"),
+			fmt.Sprintf("%d", e.Prompts),
+			fmt.Sprintf("%.1fh", e.Hours))
+		sb.WriteString(o.color(row, pal.Dim))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,247 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// Partial blocks in eighths, from empty to full, and their ASCII fallbacks.
+var (
+	eighthBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	asciiBlocks  = []string{" ", ".", ".", ":", ":", "|", "|", "#", "#"}
+)
+
+// DefaultChartHeight is the number of rows of a block chart.
+const DefaultChartHeight = 8
+
+// UTF8Locale reports whether the locale environment selects UTF-8, which
+// charts need for their block characters.
+func UTF8Locale() bool {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// blocks returns the partial block characters for the output's charset.
+func (o *Output) blocks() []string {
+	if o.ASCII {
+		return asciiBlocks
+	}
+	return eighthBlocks
+}
+
+// Sparkline renders values as a single line of partial blocks scaled to the
+// largest value, coloring each cell by the family with the most activity.
+func (o *Output) Sparkline(s report.Series) string {
+	blocks := o.blocks()
+	peak := s.Max()
+
+	var sb strings.Builder
+	for _, p := range s.Points {
+		level := 0
+		if peak > 0 && p.Total > 0 {
+			level = max(1, int(p.Total/peak*8+0.5))
+		}
+		cell := blocks[level]
+		if o.NoColor || level == 0 {
+			sb.WriteString(cell)
+			continue
+		}
+		sb.WriteString(o.familyColor(dominantFamily(p)) + cell + Reset)
+	}
+	return sb.String()
+}
+
+// RenderChart formats a series as a block chart of the given height, with
+// each column stacked by model family, a value axis, time labels and a
+// legend.
+func (o *Output) RenderChart(s report.Series, height int) string {
+	pal := o.palette()
+	if height < 1 {
+		height = DefaultChartHeight
+	}
+	blocks := o.blocks()
+	peak := s.Max()
+
+	var sb strings.Builder
+	sb.WriteString(o.color("  "+s.Title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+	if peak == 0 {
+		sb.WriteString(o.color("  No activity in this range.", pal.Muted))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	top, bottom := formatChartValue(peak, s.Unit), formatChartValue(0, s.Unit)
+	gutter := max(len(top), len(bottom))
+	axis := Vertical
+	if o.ASCII {
+		axis = "|"
+	}
+
+	// Each column's stack in eighths of a row, family by family
+	units := float64(height * 8)
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case height - 1:
+			label = top
+		case 0:
+			label = bottom
+		}
+		var line strings.Builder
+		line.WriteString(o.color(fmt.Sprintf("  %*s ", gutter, label), pal.Dim))
+		line.WriteString(o.color(axis, pal.Border))
+		for i, p := range s.Points {
+			filled := int(p.Total/peak*units + 0.5)
+			if p.Total > 0 {
+				filled = max(filled, 1)
+			}
+			level := min(max(filled-row*8, 0), 8)
+			cell := blocks[level]
+			if level > 0 {
+				family := stackFamily(s, p, peak, units, row)
+				if o.NoColor && level == 8 {
+					cell = o.familyBlock(s, family)
+				} else if !o.NoColor {
+					cell = o.familyColor(family) + cell + Reset
+				}
+			}
+			if i > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(cell)
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+
+	// Time labels under the columns
+	pad := strings.Repeat(" ", 2+gutter+2)
+	sb.WriteString(pad)
+	sb.WriteString(o.color(chartLabels(s), pal.Dim))
+	sb.WriteString("\n\n")
+
+	sb.WriteString("  ")
+	sb.WriteString(o.chartLegend(s))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatChartValue formats an axis value in the series unit.
+func formatChartValue(v float64, unit string) string {
+	if unit == "h" {
+		return fmt.Sprintf("%.1fh", v)
+	}
+	return fmt.Sprintf("%.0f", v)
+}
+
+// chartLabels places time labels under the columns, two cells per column:
+// every sixth hour for hourly charts and every Monday for daily ones.
+func chartLabels(s report.Series) string {
+	line := []rune(strings.Repeat(" ", len(s.Points)*2))
+	free := 0
+	for i, p := range s.Points {
+		start := p.Start.Local()
+		label := ""
+		switch s.Chart {
+		case report.ChartDaily:
+			if start.Weekday() == 1 {
+				label = start.Format("Jan 2")
+			}
+		default:
+			if start.Hour()%6 == 0 {
+				label = start.Format("15h")
+			}
+		}
+		pos := i * 2
+		if label == "" || pos < free || pos+len(label) > len(line) {
+			continue
+		}
+		copy(line[pos:], []rune(label))
+		free = pos + len(label) + 1
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+// chartLegend names each family with its fill and total.
+func (o *Output) chartLegend(s report.Series) string {
+	pal := o.palette()
+	totals := s.Totals()
+	parts := make([]string, 0, len(s.Families))
+	for _, family := range s.Families {
+		label := fmt.Sprintf("%s %s", o.Models.Display(family), formatChartValue(totals[family], s.Unit))
+		block := o.familyBlock(s, family)
+		if o.NoColor {
+			parts = append(parts, block+" "+label)
+			continue
+		}
+		parts = append(parts, o.familyColor(family)+block+Reset+" "+pal.Dim+label+Reset)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// familyBlock returns the full-cell fill of a family. Without colors,
+// families are told apart by their fill, as in stacked progress bars.
+func (o *Output) familyBlock(s report.Series, family string) string {
+	if o.ASCII {
+		return "#"
+	}
+	if !o.NoColor {
+		return FillBlock
+	}
+	for i, f := range s.Families {
+		if f == family {
+			return segmentBlocks[i%len(segmentBlocks)]
+		}
+	}
+	return FillBlock
+}
+
+// familyColor returns the ANSI color for a model family.
+func (o *Output) familyColor(family string) string {
+	pal := o.palette()
+	if c := HexColor(o.Models.Color(family)); c != "" {
+		return c
+	}
+	return pal.Accent
+}
+
+// dominantFamily returns the family with the most activity in a point.
+func dominantFamily(p report.Point) string {
+	best, value := "", 0.0
+	for family, v := range p.Families {
+		if v > value || (v == value && family < best) {
+			best, value = family, v
+		}
+	}
+	return best
+}
+
+// stackFamily returns the family covering most of a chart cell, stacking the
+// point's families bottom up in series order.
+func stackFamily(s report.Series, p report.Point, peak, units float64, row int) string {
+	cellLow, cellHigh := float64(row*8), float64(row*8+8)
+	best, covered := "", 0.0
+	base := 0.0
+	for _, family := range s.Families {
+		v := p.Families[family] / peak * units
+		low, high := max(base, cellLow), min(base+v, cellHigh)
+		if high-low > covered {
+			best, covered = family, high-low
+		}
+		base += v
+	}
+	if best == "" {
+		return dominantFamily(p)
+	}
+	return best
+}
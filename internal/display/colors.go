@@ -1,6 +1,12 @@
 // Package display handles terminal output formatting with colors and progress bars.
 package display
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // ANSI color codes for terminal output
 const (
 	Reset = "\033[0m"
@@ -28,17 +34,18 @@ const (
 // Bold modifier
 const Bold = "\033[1m"
 
-// GetUsageColor returns an appropriate color based on usage percentage.
-// 0-50%: Green, 50-75%: Yellow, 75-100%: Red
-func GetUsageColor(percentage float64) string {
-	switch {
-	case percentage < 50:
-		return Green
-	case percentage < 75:
-		return Yellow
-	default:
-		return Red
+// HexColor converts a "#RRGGBB" string into a truecolor foreground code.
+// Invalid values return an empty string.
+func HexColor(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return ""
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ""
 	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", v>>16, (v>>8)&0xff, v&0xff)
 }
 
 // Colorize wraps text with a color code and reset.
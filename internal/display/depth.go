@@ -0,0 +1,201 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ColorDepth is the number of colors a terminal can show.
+type ColorDepth int
+
+// Supported color depths, from none to 24-bit.
+const (
+	DepthNone ColorDepth = iota
+	Depth16
+	Depth256
+	DepthTrueColor
+)
+
+// Color modes for --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ParseColorMode validates a --color value.
+func ParseColorMode(s string) (string, error) {
+	switch mode := strings.ToLower(s); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid color mode %q (want auto, always or never)", s)
+}
+
+// ResolveColorDepth returns the color depth for a mode. Auto disables color
+// when f is not a terminal; always keeps color but still honours the depth
+// the environment reports.
+func ResolveColorDepth(mode string, f *os.File) ColorDepth {
+	switch mode {
+	case ColorNever:
+		return DepthNone
+	case ColorAlways:
+		return max(envColorDepth(), Depth16)
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return DepthNone
+	}
+	return envColorDepth()
+}
+
+// envColorDepth detects the color depth from COLORTERM, TERM and terminfo.
+func envColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return DepthTrueColor
+	}
+
+	name := os.Getenv("TERM")
+	if name == "dumb" {
+		return DepthNone
+	}
+	switch n := terminfoColors(name); {
+	case n >= 1<<24:
+		return DepthTrueColor
+	case n >= 256:
+		return Depth256
+	case n > 0:
+		return Depth16
+	}
+
+	// No terminfo entry; fall back to naming conventions
+	switch {
+	case strings.HasSuffix(name, "-direct") || strings.Contains(name, "truecolor"):
+		return DepthTrueColor
+	case strings.Contains(name, "256color"):
+		return Depth256
+	}
+	return Depth16
+}
+
+var (
+	truecolorPattern = regexp.MustCompile(`\x1b\[38;2;(\d+);(\d+);(\d+)m`)
+	sgrPattern       = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// Apply rewrites the truecolor escapes in s for the depth: they become the
+// nearest 256-color or 16-color codes, or are stripped with all other styling
+// when there is no color.
+func (d ColorDepth) Apply(s string) string {
+	switch d {
+	case DepthTrueColor:
+		return s
+	case DepthNone:
+		return sgrPattern.ReplaceAllString(s, "")
+	}
+	return truecolorPattern.ReplaceAllStringFunc(s, func(esc string) string {
+		m := truecolorPattern.FindStringSubmatch(esc)
+		r, _ := strconv.Atoi(m[1])
+		g, _ := strconv.Atoi(m[2])
+		b, _ := strconv.Atoi(m[3])
+		if d == Depth256 {
+			return fmt.Sprintf("\033[38;5;%dm", nearest256(r, g, b))
+		}
+		return fmt.Sprintf("\033[%dm", nearest16(r, g, b))
+	})
+}
+
+// colorWriter downgrades colors in everything written through it.
+type colorWriter struct {
+	w     io.Writer
+	depth ColorDepth
+}
+
+// NewWriter returns a writer that adapts colors to depth before writing to w.
+func NewWriter(w io.Writer, depth ColorDepth) io.Writer {
+	if depth == DepthTrueColor {
+		return w
+	}
+	return &colorWriter{w: w, depth: depth}
+}
+
+func (c *colorWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(c.w, c.depth.Apply(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// cubeLevels are the channel values of the xterm 6x6x6 color cube.
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// nearest256 returns the xterm 256-color index closest to an RGB color,
+// choosing between the color cube and the grayscale ramp.
+func nearest256(r, g, b int) int {
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDistance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// Grayscale ramp: 232-255 are 8, 18, ..., 238
+	avg := (r + g + b) / 3
+	gi24 := min(max((avg-3)/10, 0), 23)
+	level := 8 + 10*gi24
+	if colorDistance(r, g, b, level, level, level) < cubeDist {
+		return 232 + gi24
+	}
+	return cube
+}
+
+// nearestLevel returns the index of the cube level closest to v.
+func nearestLevel(v int) int {
+	best := 0
+	for i, level := range cubeLevels {
+		if abs(v-level) < abs(v-cubeLevels[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// ansi16 holds the xterm default RGB values of the 16 basic colors.
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// nearest16 returns the SGR foreground code (30-37, 90-97) of the basic
+// color closest to an RGB color.
+func nearest16(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, c := range ansi16 {
+		if d := colorDistance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best < 8 {
+		return 30 + best
+	}
+	return 90 + best - 8
+}
+
+// colorDistance returns the squared distance between two RGB colors.
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,186 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/figlet"
+)
+
+// Header color modes.
+const (
+	HeaderSolid      = "solid"      // The theme's header color
+	HeaderHorizontal = "horizontal" // Gradient from left to right
+	HeaderVertical   = "vertical"   // Gradient from top to bottom
+	HeaderGlyph      = "glyph"      // One gradient step per character
+	HeaderUsage      = "usage"      // Color of the current weekly usage level
+)
+
+// ParseHeaderMode validates a header color mode.
+func ParseHeaderMode(s string) (string, error) {
+	switch mode := strings.ToLower(s); mode {
+	case "", HeaderSolid:
+		return HeaderSolid, nil
+	case HeaderHorizontal, HeaderVertical, HeaderGlyph, HeaderUsage:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid header color mode %q (want solid, horizontal, vertical, glyph or usage)", s)
+}
+
+// ParseGradient parses comma-separated "#RRGGBB" gradient stops; at least
+// two are needed.
+func ParseGradient(spec string) ([]string, error) {
+	var stops []string
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if HexColor(s) == "" {
+			return nil, fmt.Errorf("expected a #RRGGBB color, got %q", s)
+		}
+		stops = append(stops, s)
+	}
+	if len(stops) < 2 {
+		return nil, fmt.Errorf("a gradient needs at least two colors, got %q", spec)
+	}
+	return stops, nil
+}
+
+// renderHeader creates the header: figlet ASCII art, or a plain title in
+// the condensed layout, colored according to the header mode.
+func (o *Output) renderHeader(text string, usage *claude.UsageData) string {
+	if o.condensed() {
+		return o.paintArt(textArt([]string{text}), usage, true)
+	}
+	art := o.Font.RenderArt(text)
+	trimArt(&art)
+	return o.paintArt(art, usage, false)
+}
+
+// textArt wraps plain lines as art, treating each column as its own glyph.
+func textArt(lines []string) figlet.Art {
+	art := figlet.Art{}
+	for _, line := range lines {
+		row := []rune(line)
+		owners := make([]int, len(row))
+		for i := range owners {
+			owners[i] = i
+		}
+		art.Rows = append(art.Rows, row)
+		art.Owners = append(art.Owners, owners)
+		art.Glyphs = max(art.Glyphs, len(row))
+	}
+	return art
+}
+
+// paintArt colors art cell by cell according to the header mode.
+func (o *Output) paintArt(art figlet.Art, usage *claude.UsageData, bold bool) string {
+	var sb strings.Builder
+	for row, line := range art.Rows {
+		if row > 0 {
+			sb.WriteString("\n")
+		}
+		if o.NoColor {
+			sb.WriteString(string(line))
+			continue
+		}
+		if bold {
+			sb.WriteString(Bold)
+		}
+		current := ""
+		for col, c := range line {
+			color := o.headerCellColor(art, row, col, usage)
+			if color != current && c != ' ' {
+				sb.WriteString(color)
+				current = color
+			}
+			sb.WriteRune(c)
+		}
+		sb.WriteString(Reset)
+	}
+	return sb.String()
+}
+
+// trimArt drops trailing blank rows, which fonts reserve for descenders, and
+// trailing spaces; the layout adds its own spacing.
+func trimArt(art *figlet.Art) {
+	for i, row := range art.Rows {
+		n := len(row)
+		for n > 0 && row[n-1] == ' ' {
+			n--
+		}
+		art.Rows[i], art.Owners[i] = row[:n], art.Owners[i][:n]
+	}
+	for len(art.Rows) > 1 && len(art.Rows[len(art.Rows)-1]) == 0 {
+		art.Rows = art.Rows[:len(art.Rows)-1]
+		art.Owners = art.Owners[:len(art.Owners)-1]
+	}
+}
+
+// headerCellColor returns the ANSI color for one cell of the header.
+func (o *Output) headerCellColor(art figlet.Art, row, col int, usage *claude.UsageData) string {
+	pal := o.palette()
+	switch o.HeaderMode {
+	case HeaderUsage:
+		if usage != nil {
+			return pal.Usage(usage.WeeklyPercentage())
+		}
+	case HeaderHorizontal:
+		return o.gradientAt(col, maxRowWidth(art.Rows))
+	case HeaderVertical:
+		return o.gradientAt(row, len(art.Rows))
+	case HeaderGlyph:
+		return o.gradientAt(art.Owners[row][col], art.Glyphs)
+	}
+	return pal.Header
+}
+
+// gradientAt returns the gradient color at step i of n.
+func (o *Output) gradientAt(i, n int) string {
+	pal := o.palette()
+	stops := o.HeaderGradient
+	if len(stops) < 2 {
+		if o.Theme.Header == "" {
+			return pal.Header // Themes without a header color stay plain
+		}
+		stops = []string{o.Theme.Header, shade(o.Theme.Header, 0.6)}
+	}
+	t := 0.0
+	if n > 1 {
+		t = float64(i) / float64(n-1)
+	}
+
+	// Interpolate between the two stops around t
+	pos := t * float64(len(stops)-1)
+	k := min(int(pos), len(stops)-2)
+	return HexColor(mix(stops[k], stops[k+1], pos-float64(k)))
+}
+
+// maxRowWidth returns the length of the longest row.
+func maxRowWidth(rows [][]rune) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// rgb splits a "#RRGGBB" color into its channels.
+func rgb(hex string) (r, g, b float64) {
+	v, _ := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	return float64(v >> 16), float64((v >> 8) & 0xff), float64(v & 0xff)
+}
+
+// mix linearly interpolates between two "#RRGGBB" colors.
+func mix(from, to string, t float64) string {
+	r1, g1, b1 := rgb(from)
+	r2, g2, b2 := rgb(to)
+	lerp := func(a, b float64) int { return int(a + (b-a)*t + 0.5) }
+	return fmt.Sprintf("#%02X%02X%02X", lerp(r1, r2), lerp(g1, g2), lerp(b1, b2))
+}
+
+// shade darkens a "#RRGGBB" color by scaling its channels by f.
+func shade(hex string, f float64) string {
+	return mix("#000000", hex, f)
+}
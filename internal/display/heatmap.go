@@ -0,0 +1,143 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// Heatmap cells by level, from no activity to the busiest days, and the
+// fills used when colors are disabled.
+var (
+	heatCell        = "■"
+	heatBlocks      = []string{"·", "░", "▒", "▓", "█"}
+	heatASCIIBlocks = []string{".", "-", "+", "*", "#"}
+)
+
+// heatLevels is the number of activity levels above zero.
+const heatLevels = 4
+
+// RenderHeatmap formats daily activity as a calendar grid, one column per
+// week and one row per weekday, with month labels and a legend.
+func (o *Output) RenderHeatmap(h *report.Heatmap) string {
+	pal := o.palette()
+	name := "Active hours"
+	if h.Metric == report.MetricPrompts {
+		name = "Prompts"
+	}
+	title := fmt.Sprintf("%s per day, %s – %s", name,
+		h.From.Format("Jan 2, 2006"), h.To.Add(-time.Nanosecond).Format("Jan 2, 2006"))
+
+	var sb strings.Builder
+	sb.WriteString(o.color("  "+title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+
+	peak := 0.0
+	for _, d := range h.Days {
+		peak = max(peak, d.Value)
+	}
+	weeks := (len(h.Days) + 6) / 7
+	gutter := "      " // "  Mon "
+
+	// Month labels over the first week of each month
+	labels := []rune(strings.Repeat(" ", weeks*2))
+	free := 0
+	for w := 0; w < weeks; w++ {
+		monday := h.Days[w*7].Date
+		if w > 0 && monday.Month() == h.Days[(w-1)*7].Date.Month() {
+			continue
+		}
+		name := monday.Format("Jan")
+		pos := w * 2
+		if pos < free || pos+len(name) > len(labels) {
+			continue
+		}
+		copy(labels[pos:], []rune(name))
+		free = pos + len(name) + 1
+	}
+	sb.WriteString(gutter)
+	sb.WriteString(o.color(strings.TrimRight(string(labels), " "), pal.Dim))
+	sb.WriteString("\n")
+
+	for weekday := 0; weekday < 7; weekday++ {
+		label := ""
+		if weekday%2 == 0 {
+			label = h.Days[weekday].Date.Format("Mon")
+		}
+		var line strings.Builder
+		line.WriteString(o.color(fmt.Sprintf("  %-3s ", label), pal.Dim))
+		for w := 0; w < weeks; w++ {
+			i := w*7 + weekday
+			if i >= len(h.Days) {
+				break
+			}
+			line.WriteString(o.heatCell(heatLevel(h.Days[i].Value, peak)))
+			line.WriteString(" ")
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+
+	// Legend and summary
+	sb.WriteString("\n")
+	sb.WriteString(gutter)
+	sb.WriteString(o.color("Less ", pal.Dim))
+	for level := 0; level <= heatLevels; level++ {
+		sb.WriteString(o.heatCell(level))
+		sb.WriteString(" ")
+	}
+	sb.WriteString(o.color("More", pal.Dim))
+	sb.WriteString("\n")
+
+	summary := fmt.Sprintf("%s over %d active days", formatMetric(h.Total(), h.Metric), h.ActiveDays())
+	if busiest, ok := h.Busiest(); ok {
+		summary += fmt.Sprintf(" · busiest %s (%s)", busiest.Date.Format("Mon Jan 2"), formatMetric(busiest.Value, h.Metric))
+	}
+	sb.WriteString(gutter)
+	sb.WriteString(o.color(summary, pal.Text))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatMetric formats a heatmap value with its unit.
+func formatMetric(v float64, metric report.Metric) string {
+	if metric == report.MetricPrompts {
+		return fmt.Sprintf("%.0f prompts", v)
+	}
+	return fmt.Sprintf("%.1fh", v)
+}
+
+// heatLevel buckets a value into 0 (none) through heatLevels, relative to
+// the busiest day.
+func heatLevel(v, peak float64) int {
+	if v <= 0 || peak <= 0 {
+		return 0
+	}
+	return min(heatLevels, 1+int(v/peak*heatLevels*0.9999))
+}
+
+// heatCell draws one day at an activity level: a colored square, or a
+// denser fill per level when colors are disabled.
+func (o *Output) heatCell(level int) string {
+	pal := o.palette()
+	blocks, cell := heatBlocks, heatCell
+	if o.ASCII {
+		blocks, cell = heatASCIIBlocks, "#"
+	}
+	if o.NoColor {
+		return blocks[level]
+	}
+	if level == 0 {
+		return pal.Muted + blocks[0] + Reset
+	}
+	// Blend from the muted color towards the bar color
+	muted, bar := o.Theme.Muted, o.Theme.Bar
+	if muted == "" || bar == "" {
+		return pal.Accent + cell + Reset
+	}
+	steps := []float64{0.3, 0.55, 0.8, 1}
+	return HexColor(mix(muted, bar, steps[level-1])) + cell + Reset
+}
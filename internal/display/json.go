@@ -0,0 +1,216 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// jsonUsage is the machine-readable form of UsageData.
+type jsonUsage struct {
+	Tier      string        `json:"tier"`
+	Cycle     jsonCycle     `json:"cycle"`
+	Weekly    jsonWeekly    `json:"weekly"`
+	Projects  []jsonProject `json:"projects"`
+	Models    []jsonModel   `json:"models"`
+	Limit     *jsonLimit    `json:"limit_reached,omitempty"`
+	BurnRate  jsonBurnRate  `json:"burn_rate"`
+	Sessions  int           `json:"sessions"`
+	Today     int           `json:"sessions_today"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type jsonCycle struct {
+	Prompts        int       `json:"prompts"`
+	PromptLimitMin int       `json:"prompt_limit_min"`
+	PromptLimitMax int       `json:"prompt_limit_max"`
+	Start          time.Time `json:"start"`
+	ResetAt        time.Time `json:"reset_at"`
+	ResetInSeconds int64     `json:"reset_in_seconds"`
+	ResetExact     bool      `json:"reset_exact"` // Reset advertised by a limit message
+}
+
+type jsonWeekly struct {
+	SonnetHours    float64   `json:"sonnet_hours"`
+	OpusHours      float64   `json:"opus_hours"`
+	TotalHours     float64   `json:"total_hours"`
+	LimitHours     float64   `json:"limit_hours"`
+	Percentage     float64   `json:"percentage"`
+	PaceHours      float64   `json:"pace_hours"` // Hours an even pace would have used by now
+	HoursMode      string    `json:"hours_mode"` // How the hours above count parallel sessions
+	Summed         jsonHours `json:"summed"`     // Each session's span in full
+	Union          jsonHours `json:"union"`      // Parallel sessions counted once
+	Prompts        int       `json:"prompts"`
+	Start          time.Time `json:"start"`
+	ResetAt        time.Time `json:"reset_at"`
+	ResetInSeconds int64     `json:"reset_in_seconds"`
+	ResetExact     bool      `json:"reset_exact"` // Reset advertised by a limit message
+
+	Families []jsonFamilyCap `json:"families,omitempty"` // Per-family caps from custom tiers
+}
+
+type jsonHours struct {
+	SonnetHours float64 `json:"sonnet_hours"`
+	OpusHours   float64 `json:"opus_hours"`
+	TotalHours  float64 `json:"total_hours"`
+}
+
+type jsonFamilyCap struct {
+	Family     string  `json:"family"`
+	Hours      float64 `json:"hours"`
+	LimitHours float64 `json:"limit_hours"`
+	Within     string  `json:"within"` // Weekly total these hours are also counted in
+}
+
+type jsonBurnRate struct {
+	PromptsPerHour float64        `json:"prompts_per_hour"`
+	HoursPerDay    float64        `json:"hours_per_day"`
+	TokensPerHour  float64        `json:"tokens_per_hour"`
+	Cycle          jsonProjection `json:"cycle"`  // Prompts against the 5h cap
+	Weekly         jsonProjection `json:"weekly"` // Hours against the weekly cap
+}
+
+type jsonProjection struct {
+	Projected        bool       `json:"projected"`
+	ExhaustAt        *time.Time `json:"exhaust_at,omitempty"`
+	ExhaustInSeconds int64      `json:"exhaust_in_seconds"`
+	BeforeReset      bool       `json:"before_reset"`
+}
+
+type jsonLimit struct {
+	claude.LimitEvent
+	Window string `json:"window"`
+}
+
+type jsonProject struct {
+	claude.ProjectUsage
+	TotalHours float64 `json:"total_hours"`
+	Share      float64 `json:"share"` // Percentage of all projects' hours
+}
+
+type jsonModel struct {
+	claude.ModelUsage
+	Share float64 `json:"share"` // Percentage of weekly model hours
+}
+
+// RenderJSON produces indented JSON for usage data.
+func (o *Output) RenderJSON(usage *claude.UsageData) (string, error) {
+	out := jsonUsage{
+		Tier: usage.TierName,
+		Cycle: jsonCycle{
+			Prompts:        usage.CyclePrompts,
+			PromptLimitMin: usage.Tier.Cycle5hMin,
+			PromptLimitMax: usage.Tier.Cycle5hMax,
+			Start:          usage.CycleStartTime,
+			ResetAt:        usage.LastUpdated.Add(usage.CycleResetIn),
+			ResetInSeconds: int64(usage.CycleResetIn.Seconds()),
+			ResetExact:     usage.CycleResetExact,
+		},
+		Weekly: jsonWeekly{
+			SonnetHours:    usage.WeeklySonnetHours,
+			OpusHours:      usage.WeeklyOpusHours,
+			TotalHours:     usage.TotalWeeklyHours(),
+			LimitHours:     usage.Tier.GetTotalWeeklyMax(),
+			Percentage:     usage.WeeklyPercentage(),
+			PaceHours:      usage.PaceHours(),
+			HoursMode:      usage.HoursMode,
+			Summed:         jsonHours{usage.SummedSonnetHours, usage.SummedOpusHours, usage.SummedWeeklyHours()},
+			Union:          jsonHours{usage.UnionSonnetHours, usage.UnionOpusHours, usage.UnionSonnetHours + usage.UnionOpusHours},
+			Prompts:        usage.WeeklyPrompts,
+			Start:          usage.WeeklyStartTime,
+			ResetAt:        usage.LastUpdated.Add(usage.WeeklyResetIn),
+			ResetInSeconds: int64(usage.WeeklyResetIn.Seconds()),
+			ResetExact:     usage.WeeklyResetExact,
+		},
+		Projects:  []jsonProject{},
+		Models:    []jsonModel{},
+		Sessions:  usage.SessionsCount,
+		Today:     usage.SessionsToday,
+		UpdatedAt: usage.LastUpdated,
+	}
+
+	burn := usage.BurnRate()
+	out.BurnRate = jsonBurnRate{
+		PromptsPerHour: burn.PromptsPerHour,
+		HoursPerDay:    burn.HoursPerDay,
+		TokensPerHour:  burn.TokensPerHour,
+		Cycle:          projectionJSON(burn.Cycle),
+		Weekly:         projectionJSON(burn.Weekly),
+	}
+
+	if e := usage.LimitReached; e != nil {
+		out.Limit = &jsonLimit{LimitEvent: *e, Window: e.Window()}
+	}
+
+	for _, family := range usage.Tier.ModelLimitFamilies() {
+		out.Weekly.Families = append(out.Weekly.Families, jsonFamilyCap{
+			Family:     family,
+			Hours:      usage.FamilyHours(family),
+			LimitHours: usage.Tier.ModelLimits[family].Max,
+			Within:     claude.WeeklyFamily(family),
+		})
+	}
+
+	total := usage.SummedWeeklyHours() // Project hours are summed in either mode
+	for _, p := range usage.Projects {
+		out.Projects = append(out.Projects, projectJSON(p, total))
+	}
+
+	modelHours := 0.0
+	for _, m := range usage.Models {
+		modelHours += m.Hours
+	}
+	for _, m := range usage.Models {
+		jm := jsonModel{ModelUsage: m}
+		if modelHours > 0 {
+			jm.Share = m.Hours / modelHours * 100
+		}
+		out.Models = append(out.Models, jm)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RenderProjectsJSON produces indented JSON for a project list, with the
+// same total hours and shares as the projects table.
+func (o *Output) RenderProjectsJSON(projects []claude.ProjectUsage, totalHours float64) (string, error) {
+	out := make([]jsonProject, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, projectJSON(p, totalHours))
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// projectJSON adds a project's total hours and its share of totalHours.
+func projectJSON(p claude.ProjectUsage, totalHours float64) jsonProject {
+	jp := jsonProject{ProjectUsage: p, TotalHours: p.TotalHours()}
+	if totalHours > 0 {
+		jp.Share = p.TotalHours() / totalHours * 100
+	}
+	return jp
+}
+
+// projectionJSON converts an exhaustion projection, omitting the time when
+// nothing is projected.
+func projectionJSON(p claude.Projection) jsonProjection {
+	out := jsonProjection{
+		Projected:        p.Projected,
+		ExhaustInSeconds: int64(p.ExhaustIn.Seconds()),
+		BeforeReset:      p.BeforeReset,
+	}
+	if p.Projected {
+		at := p.ExhaustAt
+		out.ExhaustAt = &at
+	}
+	return out
+}
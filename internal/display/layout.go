@@ -0,0 +1,102 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+	"golang.org/x/text/width"
+)
+
+// Auto-sizing bounds for progress bars fitted to the terminal.
+const (
+	minAutoWidth  = 20
+	maxAutoWidth  = 80
+	layoutMargin  = 2 // Columns kept free on each side
+	defaultWidth  = 42
+	minFixedWidth = 20
+)
+
+// TerminalWidth returns the column count of f, or 0 when f is not a terminal.
+func TerminalWidth(f *os.File) int {
+	fd := int(f.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// AutoWidth returns a progress bar width that fits a terminal of the given
+// column count, leaving a margin on each side.
+func AutoWidth(termWidth int) int {
+	return min(max(termWidth-2*layoutMargin, minAutoWidth), maxAutoWidth)
+}
+
+// VisibleWidth returns the number of columns s occupies, ignoring ANSI
+// escape sequences.
+func VisibleWidth(s string) int {
+	n := 0
+	for _, r := range sgrPattern.ReplaceAllString(s, "") {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// runeWidth returns the terminal columns of r: two for East Asian wide and
+// fullwidth characters such as CJK and most emoji, none for combining marks
+// and format characters, and one otherwise.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// blockWidth returns the widest visible line of text.
+func blockWidth(text string) int {
+	width := 0
+	for _, line := range strings.Split(text, "\n") {
+		width = max(width, VisibleWidth(line))
+	}
+	return width
+}
+
+// padLines indents every non-empty line of text by n spaces.
+func padLines(text string, n int) string {
+	if n <= 0 {
+		return text
+	}
+	padding := strings.Repeat(" ", n)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = padding + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// condensed reports whether the terminal is too narrow for the figlet
+// header, in which case the full display uses its condensed layout.
+func (o *Output) condensed() bool {
+	return o.TermWidth > 0 && o.Font.Width(o.HeaderText)+2*layoutMargin > o.TermWidth
+}
+
+// center indents text so its widest line sits in the middle of the terminal.
+// It leaves text unchanged when the terminal width is unknown or too narrow.
+func (o *Output) center(text string) string {
+	if o.TermWidth <= 0 {
+		return text
+	}
+	return padLines(text, (o.TermWidth-blockWidth(text))/2)
+}
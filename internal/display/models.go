@@ -0,0 +1,53 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// modelRow is the column layout shared by the models header and rows.
+const modelRow = "  %s %-10s %10s %8s %7s"
+
+// RenderModels formats per-model-version usage as a table.
+func (o *Output) RenderModels(title string, models []claude.ModelUsage) string {
+	pal := o.palette()
+	var sb strings.Builder
+
+	sb.WriteString(o.color("  "+title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+
+	if len(models) == 0 {
+		sb.WriteString(o.color("  No model activity in this range.", pal.Muted))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	totalHours := 0.0
+	for _, m := range models {
+		totalHours += m.Hours
+	}
+
+	header := fmt.Sprintf(modelRow, fit("Model", 24), "Family", "Responses", "Hours", "Share")
+	sb.WriteString(o.color(header, pal.Text))
+	sb.WriteString("\n")
+	sb.WriteString(o.color("  "+strings.Repeat(Horizontal, len(header)-2), pal.Border))
+	sb.WriteString("\n")
+
+	for _, m := range models {
+		share := 0.0
+		if totalHours > 0 {
+			share = m.Hours / totalHours * 100
+		}
+		row := fmt.Sprintf(modelRow, fit(m.Model.Display, 24), m.Model.Family,
+			fmt.Sprintf("%d", m.Responses),
+			fmt.Sprintf("%.1fh", m.Hours),
+			fmt.Sprintf("%.1f%%", share))
+		sb.WriteString(o.color(row, HexColor(m.Model.Color)))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -4,26 +4,70 @@ package display
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/injaneity/vibe-monitor/internal/claude"
 	"github.com/injaneity/vibe-monitor/internal/figlet"
+	"github.com/injaneity/vibe-monitor/internal/history"
 )
 
 // Output combines all display components into final terminal output.
 type Output struct {
-	NoColor bool
-	Width   int
-	Offset  int // Left padding for logo alignment
+	NoColor    bool
+	Width      int
+	Offset     int  // Left padding for logo alignment
+	ShowModels bool // List usage per model version under the stats
+
+	// TermWidth is the terminal's column count, or 0 when unknown. When set,
+	// the full display is centered, and switches to a condensed layout with
+	// a plain title when the figlet header does not fit.
+	TermWidth int
+
+	HeaderText     string       // Title rendered as the figlet header
+	Font           *figlet.Font // Font for the header
+	HeaderMode     string       // Header color mode (see HeaderSolid and friends)
+	HeaderGradient []string     // "#RRGGBB" gradient stops; empty derives them from the theme
+
+	// Layout arranges the full display (LayoutStacked or LayoutSide). The
+	// side layout falls back to stacked when the terminal is too narrow.
+	Layout string
+
+	// Widgets lists the blocks of the stacked layout, top to bottom. Empty
+	// uses DefaultWidgets.
+	Widgets []Widget
+
+	ASCII   bool           // Draw charts without Unicode block characters
+	History *history.Store // Usage history for the sparkline widget (nil hides it)
+
+	Models claude.ModelTable // Model family names and colors; the built-in table when empty
+
+	Theme      Theme      // Role colors
+	Thresholds Thresholds // Usage percentages at which colors turn medium and high
+}
+
+// palette returns the theme's colors as ANSI codes.
+func (o *Output) palette() Palette {
+	return o.Theme.Palette(o.Thresholds)
 }
 
+// DefaultHeaderText is the header title when none is configured.
+const DefaultHeaderText = "Claude Code"
+
 // NewOutput creates a new output renderer.
 func NewOutput(noColor bool, width int) *Output {
-	if width < 20 {
-		width = 42
+	if width < minFixedWidth {
+		width = defaultWidth
 	}
 	return &Output{
-		NoColor: noColor,
-		Width:   width,
+		NoColor:    noColor,
+		Width:      width,
+		HeaderText: DefaultHeaderText,
+		Font:       figlet.Default(),
+		HeaderMode: HeaderSolid,
+		Layout:     LayoutStacked,
+		ASCII:      !UTF8Locale(),
+		Theme:      Themes[DefaultTheme],
+		Thresholds: DefaultThresholds,
 	}
 }
 
@@ -34,45 +78,24 @@ func (o *Output) SetOffset(offset int) {
 
 // Render produces the complete display output for Claude Code usage.
 func (o *Output) Render(usage *claude.UsageData) string {
-	var sb strings.Builder
-
-	// 1. Figlet ASCII art header
-	header := o.renderHeader("Claude Code")
-	if o.Offset > 0 {
-		header = o.addOffset(header)
-	}
-	sb.WriteString(header)
-	sb.WriteString("\n\n")
-
-	// 2. Model-specific usage stats
-	stats := o.renderModelStats(usage)
-	if o.Offset > 0 {
-		stats = o.addOffset(stats)
-	}
-	sb.WriteString(stats)
-	sb.WriteString("\n\n")
-
-	// 3. Progress bar
-	bar := o.renderProgressBar(usage)
-	if o.Offset > 0 {
-		bar = o.addOffset(bar)
+	if o.Layout == LayoutSide {
+		if side := o.renderSide(usage); side != "" {
+			return side
+		}
 	}
-	sb.WriteString(bar)
-	sb.WriteString("\n")
 
-	return sb.String()
+	return o.center(o.renderWidgets(usage))
 }
 
-// renderHeader creates the figlet ASCII art header.
-func (o *Output) renderHeader(text string) string {
-	if o.NoColor {
-		return figlet.Render(text)
-	}
-	return figlet.RenderColored(text, ClaudeOrange)
+// withinNote marks a capped family's hours as part of the weekly total they
+// also count toward, such as " (in Sonnet)".
+func (o *Output) withinNote(family string) string {
+	return fmt.Sprintf(" (in %s)", o.Models.Display(claude.WeeklyFamily(family)))
 }
 
 // renderModelStats formats the model usage breakdown.
 func (o *Output) renderModelStats(usage *claude.UsageData) string {
+	pal := o.palette()
 	var sb strings.Builder
 	indent := "    "
 
@@ -83,9 +106,9 @@ func (o *Output) renderModelStats(usage *claude.UsageData) string {
 		sb.WriteString(sonnetLine)
 	} else {
 		sb.WriteString(indent)
-		sb.WriteString(White + "Sonnet: " + Reset)
-		sb.WriteString(ClaudeOrange + fmt.Sprintf("%.1f", usage.WeeklySonnetHours) + Reset)
-		sb.WriteString(White + fmt.Sprintf(" / %.1fh", usage.Tier.WeeklySonnetMax) + Reset)
+		sb.WriteString(pal.Text + "Sonnet: " + Reset)
+		sb.WriteString(pal.Accent + fmt.Sprintf("%.1f", usage.WeeklySonnetHours) + Reset)
+		sb.WriteString(pal.Text + fmt.Sprintf(" / %.1fh", usage.Tier.WeeklySonnetMax) + Reset)
 	}
 
 	// Opus hours (if available)
@@ -97,17 +120,150 @@ func (o *Output) renderModelStats(usage *claude.UsageData) string {
 			sb.WriteString(opusLine)
 		} else {
 			sb.WriteString(indent)
-			sb.WriteString(White + "Opus:   " + Reset)
-			sb.WriteString(ClaudeOrange + fmt.Sprintf("%.1f", usage.WeeklyOpusHours) + Reset)
-			sb.WriteString(White + fmt.Sprintf(" / %.1fh", usage.Tier.WeeklyOpusMax) + Reset)
+			sb.WriteString(pal.Text + "Opus:   " + Reset)
+			sb.WriteString(pal.Accent + fmt.Sprintf("%.1f", usage.WeeklyOpusHours) + Reset)
+			sb.WriteString(pal.Text + fmt.Sprintf(" / %.1fh", usage.Tier.WeeklyOpusMax) + Reset)
+		}
+	}
+
+	// Caps for additional model families, whose hours are part of the
+	// Sonnet (or Opus) hours above
+	for _, family := range usage.Tier.ModelLimitFamilies() {
+		limit := usage.Tier.ModelLimits[family]
+		label := fmt.Sprintf("%-8s", o.Models.Display(family)+":")
+		hours := usage.FamilyHours(family)
+		within := o.withinNote(family)
+		sb.WriteString("\n")
+		if o.NoColor {
+			sb.WriteString(fmt.Sprintf("%s%s%.1f / %.1fh%s", indent, label, hours, limit.Max, within))
+		} else {
+			sb.WriteString(indent)
+			sb.WriteString(pal.Text + label + Reset)
+			sb.WriteString(pal.Accent + fmt.Sprintf("%.1f", hours) + Reset)
+			sb.WriteString(pal.Text + fmt.Sprintf(" / %.1fh", limit.Max) + Reset)
+			sb.WriteString(pal.Dim + within + Reset)
+		}
+	}
+
+	// Time lost to parallel sessions, with the figure not being counted
+	if overlap := usage.OverlapHours(); overlap >= 0.05 {
+		summed := usage.SummedWeeklyHours()
+		union := usage.UnionSonnetHours + usage.UnionOpusHours
+		other := fmt.Sprintf("%.1fh summed", summed)
+		if usage.HoursMode != claude.HoursUnion {
+			other = fmt.Sprintf("%.1fh union", union)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(o.color(fmt.Sprintf("%sParallel: %.1fh overlapping (%s)", indent, overlap, other), pal.Dim))
+	}
+
+	// Per-model-version breakdown
+	if o.ShowModels && len(usage.Models) > 0 {
+		sb.WriteString("\n")
+		for _, m := range usage.Models {
+			sb.WriteString("\n")
+			line := fmt.Sprintf("%s  %s %5.1fh  %5d responses",
+				indent, fit(m.Model.Display, 16), m.Hours, m.Responses)
+			if o.NoColor {
+				sb.WriteString(line)
+			} else {
+				sb.WriteString(HexColor(m.Model.Color) + line + Reset)
+			}
 		}
 	}
 
 	return sb.String()
 }
 
-// renderProgressBar creates the 3-line progress bar.
-func (o *Output) renderProgressBar(usage *claude.UsageData) string {
+// renderBurnRate formats the current pace and when each cap would be hit at
+// that pace. It returns "" when there is no recent activity.
+func (o *Output) renderBurnRate(usage *claude.UsageData) string {
+	pal := o.palette()
+	b := usage.BurnRate()
+	if !b.Cycle.Projected && !b.Weekly.Projected {
+		return ""
+	}
+
+	var sb strings.Builder
+	indent := "    "
+	if o.condensed() {
+		// Projections only, in their short form
+		for _, p := range []struct {
+			name string
+			proj claude.Projection
+		}{{"5h", b.Cycle}, {"Weekly", b.Weekly}} {
+			if p.proj.Projected {
+				if sb.Len() > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(o.renderProjection("  ", p.name, p.proj))
+			}
+		}
+		return sb.String()
+	}
+	pace := fmt.Sprintf("%sPace:   %.1f prompts/h · %.1fh/day · %s tokens/h",
+		indent, b.PromptsPerHour, b.HoursPerDay, FormatTokens(int64(b.TokensPerHour)))
+	sb.WriteString(o.color(pace, pal.Dim))
+
+	for _, p := range []struct {
+		name string
+		proj claude.Projection
+	}{{"5h", b.Cycle}, {"weekly", b.Weekly}} {
+		if !p.proj.Projected {
+			continue
+		}
+		sb.WriteString("\n")
+		sb.WriteString(o.renderProjection(indent, p.name, p.proj))
+	}
+	return sb.String()
+}
+
+// renderProjection formats a single exhaustion projection line.
+func (o *Output) renderProjection(indent, name string, p claude.Projection) string {
+	pal := o.palette()
+	if p.ExhaustIn <= 0 {
+		return o.color(fmt.Sprintf("%sThe %s cap is used up", indent, name), pal.High)
+	}
+	when := "after reset"
+	color := pal.Low
+	if p.BeforeReset {
+		when = "before reset"
+		color = pal.Medium
+	}
+	line := fmt.Sprintf("%sAt this rate you hit the %s cap in %s (%s)",
+		indent, name, formatSpan(p.ExhaustIn), when)
+	if o.condensed() {
+		line = fmt.Sprintf("%s%s cap in %s (%s)", indent, name, formatSpan(p.ExhaustIn), when)
+	}
+	return o.color(line, color)
+}
+
+// formatSpan formats a duration as "Xh Ym", switching to "Xd Yh" past a day.
+func formatSpan(d time.Duration) string {
+	if d < 24*time.Hour {
+		return claude.FormatResetTime(d)
+	}
+	days := int(d.Hours()) / 24
+	return fmt.Sprintf("%dd %dh", days, int(d.Hours())%24)
+}
+
+// renderLimitNotice reports a reached limit and when it resets.
+func (o *Output) renderLimitNotice(e *claude.LimitEvent) string {
+	pal := o.palette()
+	window := "5-hour"
+	if e.Window() == claude.WindowWeekly {
+		window = "Weekly"
+	}
+	line := fmt.Sprintf("    %s limit reached · resets %s", window, e.ResetAt.Local().Format("Mon 15:04"))
+	if o.NoColor {
+		return line
+	}
+	return BoldColorize(line, pal.High)
+}
+
+// renderProgressBar creates the 3-line weekly progress bar, marking the
+// weekly pace when pace is set.
+func (o *Output) renderProgressBar(usage *claude.UsageData, pace bool) string {
 	totalHours := usage.TotalWeeklyHours()
 	maxHours := usage.Tier.GetTotalWeeklyMax()
 	timeLeft := claude.FormatResetTime(usage.WeeklyResetIn)
@@ -119,6 +275,19 @@ func (o *Output) renderProgressBar(usage *claude.UsageData) string {
 		TimeLeft: timeLeft,
 		Unit:     "h",
 		NoColor:  o.NoColor,
+		Palette:  o.palette(),
+	}
+	if pace {
+		bar.Target = usage.PaceHours()
+	}
+
+	// Stack Sonnet and Opus hours when the tier has both
+	if usage.Tier.HasOpus() {
+		bar.Segments = []Segment{
+			{Label: o.Models.Display(claude.FamilySonnet), Value: usage.WeeklySonnetHours, Color: HexColor(o.Models.Color(claude.FamilySonnet))},
+			{Label: o.Models.Display(claude.FamilyOpus), Value: usage.WeeklyOpusHours, Color: HexColor(o.Models.Color(claude.FamilyOpus))},
+		}
+		return bar.Render() + "\n " + bar.Legend()
 	}
 
 	return bar.Render()
@@ -126,6 +295,7 @@ func (o *Output) renderProgressBar(usage *claude.UsageData) string {
 
 // RenderCompact produces a single-line compact output for status bars.
 func (o *Output) RenderCompact(usage *claude.UsageData) string {
+	pal := o.palette()
 	totalHours := usage.TotalWeeklyHours()
 	maxHours := usage.Tier.GetTotalWeeklyMax()
 	percentage := usage.WeeklyPercentage()
@@ -133,11 +303,14 @@ func (o *Output) RenderCompact(usage *claude.UsageData) string {
 
 	line := fmt.Sprintf("Claude: %.1f/%.1fh (%.0f%%) | %s",
 		totalHours, maxHours, percentage, resetTime)
+	if usage.LimitReached != nil {
+		line += " | limit reached until " + usage.LimitReached.ResetAt.Local().Format("15:04")
+	}
 
 	if o.NoColor {
 		return line
 	}
-	return Colorize(line, GetUsageColor(percentage))
+	return Colorize(line, pal.Usage(percentage))
 }
 
 // addOffset adds left padding to multi-line text.
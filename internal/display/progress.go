@@ -8,16 +8,23 @@ import (
 
 // ProgressBar represents a 3-line progress bar with borders.
 type ProgressBar struct {
-	Width      int     // Total width including borders
-	Current    float64 // Current value
-	Total      float64 // Maximum value
-	TimeLeft   string  // e.g., "2h 15m"
-	Unit       string  // e.g., "h" for hours
-	ShowCost   bool    // If true, show as currency
-	CostPrefix string  // e.g., "$"
-	NoColor    bool    // Disable colors
-	HideTimer  bool    // Hide the timer in top border
-	BarColor   string  // Custom bar color (ANSI code)
+	Width       int     // Total width including borders
+	Current     float64 // Current value
+	Total       float64 // Maximum value
+	TimeLeft    string  // e.g., "2h 15m"
+	Unit        string  // e.g., "h" for hours
+	ShowCost    bool    // If true, show as currency
+	CostPrefix  string  // e.g., "$"
+	NoColor     bool    // Disable colors
+	HideTimer   bool    // Hide the timer in top border
+	BarColor    string  // Custom bar color (ANSI code)
+	Palette     Palette // Theme colors; the default theme's when zero
+	Target      float64 // Expected value at an even pace (0 hides the pace marker)
+	ValueFormat string  // Format of the values in the bottom label (default "%.1f")
+
+	// Segments stack several filled parts in one bar. When set, Current
+	// defaults to their sum; see Legend for the matching key.
+	Segments []Segment
 }
 
 // Box drawing characters
@@ -30,10 +37,20 @@ const (
 	Vertical    = "│"
 	FillBlock   = "█"
 	EmptyBlock  = "░"
+	PaceMarker  = "┃"
 )
 
+// palette returns the bar's theme colors.
+func (p *ProgressBar) palette() Palette {
+	if p.Palette == (Palette{}) {
+		return Themes[DefaultTheme].Palette(DefaultThresholds)
+	}
+	return p.Palette
+}
+
 // Render creates a 3-line progress bar string.
 func (p *ProgressBar) Render() string {
+	pal := p.palette()
 	if p.Width < 10 {
 		p.Width = 42 // Default width
 	}
@@ -43,7 +60,7 @@ func (p *ProgressBar) Render() string {
 	// Calculate progress
 	percentage := 0.0
 	if p.Total > 0 {
-		percentage = (p.Current / p.Total) * 100
+		percentage = (p.value() / p.Total) * 100
 	}
 	if percentage > 100 {
 		percentage = 100
@@ -59,17 +76,21 @@ func (p *ProgressBar) Render() string {
 	topLine := p.buildTopBorderLine(innerWidth)
 
 	// Build the progress bar middle line
-	filled := strings.Repeat(FillBlock, filledCount)
-	empty := strings.Repeat(EmptyBlock, emptyCount)
-	var middleLine string
 	barColor := p.BarColor
 	if barColor == "" {
-		barColor = ClaudeOrange // Default
+		barColor = pal.Accent // Default
 	}
-	if p.NoColor {
-		middleLine = Vertical + filled + empty + Vertical
+	var middleLine string
+	if marker := p.markerPosition(innerWidth); marker >= 0 || len(p.Segments) > 0 {
+		middleLine = p.buildCellBar(filledCount, innerWidth, marker, barColor)
 	} else {
-		middleLine = BoxColor + Vertical + Reset + barColor + filled + Reset + Gray + empty + Reset + BoxColor + Vertical + Reset
+		filled := strings.Repeat(FillBlock, filledCount)
+		empty := strings.Repeat(EmptyBlock, emptyCount)
+		if p.NoColor {
+			middleLine = Vertical + filled + empty + Vertical
+		} else {
+			middleLine = pal.Border + Vertical + Reset + barColor + filled + Reset + pal.Muted + empty + Reset + pal.Border + Vertical + Reset
+		}
 	}
 
 	// Build bottom line with orange current value
@@ -78,15 +99,111 @@ func (p *ProgressBar) Render() string {
 	return topLine + "\n" + middleLine + "\n" + bottomLine
 }
 
+// value returns Current, or the sum of the segments when Current is unset.
+func (p *ProgressBar) value() float64 {
+	if len(p.Segments) > 0 && p.Current == 0 {
+		return p.segmentTotal()
+	}
+	return p.Current
+}
+
+// markerPosition returns the bar cell of the pace target, or -1 when there
+// is no target.
+func (p *ProgressBar) markerPosition(innerWidth int) int {
+	if p.Target <= 0 || p.Total <= 0 {
+		return -1
+	}
+	pos := int(float64(innerWidth) * p.Target / p.Total)
+	return min(pos, innerWidth-1)
+}
+
+// buildCellBar creates the middle line cell by cell, for stacked segments
+// and for the pace marker drawn over the cell at marker (-1 for none).
+func (p *ProgressBar) buildCellBar(filledCount, innerWidth, marker int, barColor string) string {
+	pal := p.palette()
+	// Fill and color of each filled cell, segment by segment
+	fills := make([]string, 0, filledCount)
+	colors := make([]string, 0, filledCount)
+	if len(p.Segments) > 0 {
+		for i, n := range p.segmentCells(filledCount) {
+			for j := 0; j < n; j++ {
+				fills = append(fills, p.segmentBlock(i))
+				colors = append(colors, p.segmentColor(i))
+			}
+		}
+	}
+	for len(fills) < filledCount {
+		fills = append(fills, FillBlock)
+		colors = append(colors, barColor)
+	}
+
+	var sb strings.Builder
+	if p.NoColor {
+		sb.WriteString(Vertical)
+	} else {
+		sb.WriteString(pal.Border + Vertical + Reset)
+	}
+	current := ""
+	for i := 0; i < innerWidth; i++ {
+		cell, color := EmptyBlock, pal.Muted
+		if i < filledCount {
+			cell, color = fills[i], colors[i]
+		}
+		if i == marker {
+			cell, color = PaceMarker, pal.Text
+		}
+		if !p.NoColor && color != current {
+			if current != "" {
+				sb.WriteString(Reset)
+			}
+			sb.WriteString(color)
+			current = color
+		}
+		sb.WriteString(cell)
+	}
+	if p.NoColor {
+		sb.WriteString(Vertical)
+	} else {
+		sb.WriteString(Reset + pal.Border + Vertical + Reset)
+	}
+	return sb.String()
+}
+
+// paceLabels describes how far the value is from Target, longest form first
+// so narrow bars can fall back to a shorter one. It returns nil when there is
+// no target.
+func (p *ProgressBar) paceLabels() []string {
+	if p.Target <= 0 || p.Total <= 0 {
+		return nil
+	}
+	diff := p.value() - p.Target
+	switch {
+	case diff >= 0.05:
+		return []string{
+			fmt.Sprintf("ahead of pace by %.1f%s", diff, p.Unit),
+			fmt.Sprintf("ahead by %.1f%s", diff, p.Unit),
+			fmt.Sprintf("+%.1f%s", diff, p.Unit),
+		}
+	case diff <= -0.05:
+		return []string{
+			fmt.Sprintf("behind pace by %.1f%s", -diff, p.Unit),
+			fmt.Sprintf("behind by %.1f%s", -diff, p.Unit),
+			fmt.Sprintf("-%.1f%s", -diff, p.Unit),
+		}
+	}
+	return []string{"on pace"}
+}
+
 // buildTopBorderLine creates top border with timer or plain border.
 func (p *ProgressBar) buildTopBorderLine(innerWidth int) string {
+	pal := p.palette()
 	// If HideTimer is set, just draw a plain border
 	if p.HideTimer || p.TimeLeft == "" {
 		dashes := strings.Repeat(Horizontal, innerWidth)
 		if p.NoColor {
 			return TopLeft + dashes + TopRight
 		}
-		return BoxColor + TopLeft + dashes + TopRight + Reset
+		return pal.Border + TopLeft + dashes + TopRight + Reset
 	}
 
 	// Format: " Xh Ym until reset "
@@ -112,20 +229,38 @@ func (p *ProgressBar) buildTopBorderLine(innerWidth int) string {
 	}
 
 	// Colored: orange time, default suffix
-	return BoxColor + TopLeft + leftDashes + Reset +
-		" " + ClaudeOrange + timeStr + Reset + DimWhite + suffix + Reset + " " +
-		BoxColor + rightDashes + TopRight + Reset
+	return pal.Border + TopLeft + leftDashes + Reset +
+		" " + pal.Accent + timeStr + Reset + pal.Dim + suffix + Reset + " " +
+		pal.Border + rightDashes + TopRight + Reset
 }
 
 // buildBottomBorderLine creates bottom border with styled percentage and values.
 func (p *ProgressBar) buildBottomBorderLine(percentage float64, innerWidth int) string {
+	pal := p.palette()
+	valueFormat := p.ValueFormat
+	if valueFormat == "" {
+		valueFormat = "%.1f"
+	}
+
 	var fullLabel string
 	if p.ShowCost {
 		fullLabel = fmt.Sprintf(" %.1f%% (%s%.2f / %s%.2f) ",
-			percentage, p.CostPrefix, p.Current, p.CostPrefix, p.Total)
+			percentage, p.CostPrefix, p.value(), p.CostPrefix, p.Total)
 	} else {
-		fullLabel = fmt.Sprintf(" %.1f%% (%.1f / %.1f%s) ",
-			percentage, p.Current, p.Total, p.Unit)
+		fullLabel = fmt.Sprintf(" %.1f%% ("+valueFormat+" / "+valueFormat+"%s) ",
+			percentage, p.value(), p.Total, p.Unit)
+	}
+
+	// Append the pace, shortening or dropping it when the bar is too narrow
+	pace := ""
+	for _, candidate := range p.paceLabels() {
+		if len([]rune(fullLabel+"· "+candidate+" ")) < innerWidth {
+			pace = candidate
+			break
+		}
+	}
+	if pace != "" {
+		fullLabel += "· " + pace + " "
 	}
 	labelLen := len([]rune(fullLabel))
 
@@ -148,22 +283,29 @@ func (p *ProgressBar) buildBottomBorderLine(percentage float64, innerWidth int)
 	// Use BarColor for the current value, fallback to orange
 	valColor := p.BarColor
 	if valColor == "" {
-		valColor = ClaudeOrange
+		valColor = pal.Accent
 	}
 
 	// Colored version with highlighted current value
 	var coloredLabel string
 	if p.ShowCost {
-		coloredLabel = fmt.Sprintf(" %.1f%% ("+valColor+"%s%.2f"+Reset+DimWhite+" / %s%.2f) "+Reset,
-			percentage, p.CostPrefix, p.Current, p.CostPrefix, p.Total)
+		coloredLabel = fmt.Sprintf(" %.1f%% ("+valColor+"%s%.2f"+Reset+pal.Dim+" / %s%.2f) "+Reset,
+			percentage, p.CostPrefix, p.value(), p.CostPrefix, p.Total)
 	} else {
-		coloredLabel = fmt.Sprintf(DimWhite+" %.1f%% ("+Reset+valColor+"%.1f"+Reset+DimWhite+" / %.1f%s) "+Reset,
-			percentage, p.Current, p.Total, p.Unit)
+		coloredLabel = fmt.Sprintf(pal.Dim+" %.1f%% ("+Reset+valColor+valueFormat+Reset+pal.Dim+" / "+valueFormat+"%s) "+Reset,
+			percentage, p.value(), p.Total, p.Unit)
+	}
+	if pace != "" {
+		paceColor := pal.Low
+		if p.value() > p.Target {
+			paceColor = pal.Medium
+		}
+		coloredLabel += pal.Dim + "· " + Reset + paceColor + pace + Reset + " "
 	}
 
-	return BoxColor + BottomLeft + leftDashes + Reset +
+	return pal.Border + BottomLeft + leftDashes + Reset +
 		coloredLabel +
-		BoxColor + rightDashes + BottomRight + Reset
+		pal.Border + rightDashes + BottomRight + Reset
 }
 
 // NewProgressBar creates a progress bar with sensible defaults.
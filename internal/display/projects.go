@@ -0,0 +1,98 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// projectRow is the column layout shared by the projects header and rows.
+const projectRow = "  %s %8s %8s %8s %8s %9s %8s  %s"
+
+// shareBarWidth is the width of the inline share bar in the projects table.
+const shareBarWidth = 10
+
+// RenderProjects formats per-project usage as a table. totalHours is the
+// combined usage the share column is measured against.
+func (o *Output) RenderProjects(title string, projects []claude.ProjectUsage, totalHours float64) string {
+	pal := o.palette()
+	var sb strings.Builder
+
+	sb.WriteString(o.color("  "+title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+
+	if len(projects) == 0 {
+		sb.WriteString(o.color("  No project activity in this range.", pal.Muted))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	header := fmt.Sprintf(projectRow, fit("Project", 28), "Prompts", "Hours", "Sonnet", "Opus", "Tokens", "Sessions", "Share")
+	sb.WriteString(o.color(header, pal.Text))
+	sb.WriteString("\n")
+	ruleWidth := len(header) - 2 - len("Share") + len("100.0% ") + shareBarWidth
+	sb.WriteString(o.color("  "+strings.Repeat(Horizontal, ruleWidth), pal.Border))
+	sb.WriteString("\n")
+
+	for _, p := range projects {
+		share := 0.0
+		if totalHours > 0 {
+			share = p.TotalHours() / totalHours * 100
+		}
+		row := fmt.Sprintf(projectRow, fit(p.Name, 28),
+			fmt.Sprintf("%d", p.Prompts),
+			fmt.Sprintf("%.1fh", p.TotalHours()),
+			fmt.Sprintf("%.1fh", p.SonnetHours),
+			fmt.Sprintf("%.1fh", p.OpusHours),
+			FormatTokens(p.Tokens.Total()),
+			fmt.Sprintf("%d", p.Sessions),
+			fmt.Sprintf("%5.1f%% ", share))
+		sb.WriteString(o.color(row, pal.Dim))
+		sb.WriteString(o.shareBar(share))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// shareBar renders a small inline bar for a percentage.
+func (o *Output) shareBar(percentage float64) string {
+	pal := o.palette()
+	filled := int(percentage / 100 * shareBarWidth)
+	if filled > shareBarWidth {
+		filled = shareBarWidth
+	}
+	bar := strings.Repeat(FillBlock, filled)
+	rest := strings.Repeat(EmptyBlock, shareBarWidth-filled)
+	if o.NoColor {
+		return bar + rest
+	}
+	return pal.Accent + bar + Reset + pal.Muted + rest + Reset
+}
+
+// truncate shortens s to at most width columns, marking the cut with an
+// ellipsis.
+func truncate(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		if used+runeWidth(r) > width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		used += runeWidth(r)
+	}
+	return sb.String() + "…"
+}
+
+// fit truncates s to width columns and pads it with spaces to exactly that
+// width, which fmt's padding cannot do for wide characters.
+func fit(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", max(width-VisibleWidth(s), 0))
+}
@@ -0,0 +1,85 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// reportRow is the column layout shared by report header, rows and totals.
+const reportRow = "  %-16s %8s %8s %8s %8s %8s %9s %9s %8s"
+
+// RenderReport formats a date-range report as a table.
+func (o *Output) RenderReport(r *report.Report) string {
+	pal := o.palette()
+	var sb strings.Builder
+
+	title := fmt.Sprintf("  Usage %s → %s (by %s, %s limits, %s hours)",
+		r.From.Format("2006-01-02 15:04"), r.To.Format("2006-01-02 15:04"), r.GroupBy, r.Tier, r.Hours)
+	sb.WriteString(o.color(title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+
+	header := fmt.Sprintf(reportRow, "Period", "Prompts", "Active", "Union", "Sonnet", "Opus", "Tokens", "Sessions", "Limit")
+	sb.WriteString(o.color(header, pal.Text))
+	sb.WriteString("\n")
+	sb.WriteString(o.color("  "+strings.Repeat(Horizontal, len(header)-2), pal.Border))
+	sb.WriteString("\n")
+
+	for _, b := range r.Buckets {
+		row := reportLine(b.Label(r.GroupBy), b)
+		if b.Prompts == 0 && b.ActiveHours == 0 {
+			sb.WriteString(o.color(row, pal.Muted))
+		} else {
+			sb.WriteString(o.color(row, pal.Dim))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(o.color("  "+strings.Repeat(Horizontal, len(header)-2), pal.Border))
+	sb.WriteString("\n")
+	sb.WriteString(o.color(reportLine("Total", r.Total), Bold+pal.Header))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// reportLine formats a single report row. Buckets without a known limit show
+// a dash in the limit column.
+func reportLine(label string, b report.Bucket) string {
+	limit := "—"
+	if b.LimitHours > 0 {
+		limit = fmt.Sprintf("%.1f%%", b.LimitPercent)
+	}
+	return fmt.Sprintf(reportRow, label,
+		fmt.Sprintf("%d", b.Prompts),
+		fmt.Sprintf("%.1fh", b.ActiveHours),
+		fmt.Sprintf("%.1fh", b.UnionHours),
+		fmt.Sprintf("%.1fh", b.SonnetHours),
+		fmt.Sprintf("%.1fh", b.OpusHours),
+		FormatTokens(b.Tokens.Total()),
+		fmt.Sprintf("%d", b.Sessions),
+		limit)
+}
+
+// color applies a color unless colors are disabled.
+func (o *Output) color(text, color string) string {
+	if o.NoColor {
+		return text
+	}
+	return Colorize(text, color)
+}
+
+// FormatTokens formats a token count with a k/M/B suffix.
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000_000:
+		return fmt.Sprintf("%.1fB", float64(n)/1e9)
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	}
+	return fmt.Sprintf("%d", n)
+}
@@ -0,0 +1,149 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// Layouts for the full display.
+const (
+	LayoutStacked = "stacked" // Header, stats and progress bar stacked vertically
+	LayoutSide    = "side"    // Logo on the left, stats on the right
+)
+
+// ParseLayout validates a layout name.
+func ParseLayout(s string) (string, error) {
+	switch layout := strings.ToLower(s); layout {
+	case "", LayoutStacked:
+		return LayoutStacked, nil
+	case LayoutSide:
+		return layout, nil
+	}
+	return "", fmt.Errorf("invalid layout %q (want stacked or side)", s)
+}
+
+// Logo is the ASCII art shown beside the stats in the side layout.
+var Logo = []string{
+	`       \   |   /`,
+	`   .    \  |  /    .`,
+	`     '.  \ | /  .'`,
+	`  ____  '.\|/.'  ____`,
+	`      ''--( )--''`,
+	`  ____ .'/|\'. ____`,
+	`     .'  / | \  '.`,
+	`   '    /  |  \    '`,
+	`       /   |   \`,
+}
+
+// sideGap is the space between the logo and the stats column.
+const sideGap = 4
+
+// renderSide produces the neofetch-style layout: the logo on the left and a
+// column of labelled stats on the right. It returns "" when the terminal is
+// too narrow for both columns.
+func (o *Output) renderSide(usage *claude.UsageData) string {
+	logo := o.paintArt(textArt(Logo), usage, false)
+	stats := o.renderSideStats(usage)
+
+	out := joinColumns(logo, stats, sideGap)
+	if o.TermWidth > 0 && blockWidth(out) > o.TermWidth {
+		return ""
+	}
+	return o.center(out) + "\n"
+}
+
+// renderSideStats formats the stats column of the side layout.
+func (o *Output) renderSideStats(usage *claude.UsageData) string {
+	pal := o.palette()
+	var lines []string
+	title := o.HeaderText
+	lines = append(lines, o.color(title, Bold+pal.Header))
+	lines = append(lines, o.color(strings.Repeat(Horizontal, VisibleWidth(title)), pal.Border))
+
+	field := func(label, value string) {
+		if label != "" {
+			label += ":" // An empty label continues the previous field
+		}
+		lines = append(lines, o.color(fmt.Sprintf("%-8s", label), Bold+pal.Accent)+" "+value)
+	}
+
+	field("Tier", o.color(usage.TierName, pal.Text))
+
+	if len(usage.Models) > 0 {
+		names := make([]string, 0, 3)
+		for i, m := range usage.Models {
+			if i == 3 {
+				names = append(names, fmt.Sprintf("+%d", len(usage.Models)-i))
+				break
+			}
+			name := m.Model.Display
+			if !o.NoColor && m.Model.Color != "" {
+				name = HexColor(m.Model.Color) + name + Reset
+			}
+			names = append(names, name)
+		}
+		field("Models", strings.Join(names, o.color(", ", pal.Dim)))
+	}
+
+	cyclePct := 0.0
+	if usage.Tier.Cycle5hMax > 0 {
+		cyclePct = float64(usage.CyclePrompts) / float64(usage.Tier.Cycle5hMax) * 100
+	}
+	field("5h", o.color(fmt.Sprintf("%d / %d prompts", usage.CyclePrompts, usage.Tier.Cycle5hMax), pal.Usage(cyclePct))+
+		o.color(" · resets in "+formatSpan(usage.CycleResetIn), pal.Dim))
+
+	field("Week", o.color(fmt.Sprintf("%.1f / %.1fh (%.0f%%)", usage.TotalWeeklyHours(), usage.Tier.GetTotalWeeklyMax(), usage.WeeklyPercentage()), pal.Usage(usage.WeeklyPercentage()))+
+		o.color(" · resets in "+formatSpan(usage.WeeklyResetIn), pal.Dim))
+
+	sessions := "sessions"
+	if usage.SessionsToday == 1 {
+		sessions = "session"
+	}
+	field("Today", o.color(fmt.Sprintf("%d %s", usage.SessionsToday, sessions), pal.Text))
+
+	b := usage.BurnRate()
+	field("Burn", o.color(fmt.Sprintf("%.1f prompts/h · %.1fh/day", b.PromptsPerHour, b.HoursPerDay), pal.Text))
+	if b.Cycle.Projected && b.Cycle.BeforeReset {
+		field("", o.color("5h cap in "+formatSpan(b.Cycle.ExhaustIn), pal.Medium))
+	} else if b.Weekly.Projected && b.Weekly.BeforeReset {
+		field("", o.color("weekly cap in "+formatSpan(b.Weekly.ExhaustIn), pal.Medium))
+	}
+
+	if e := usage.LimitReached; e != nil {
+		window := "5-hour"
+		if e.Window() == claude.WindowWeekly {
+			window = "Weekly"
+		}
+		lines = append(lines, "", o.color(window+" limit reached · resets "+e.ResetAt.Local().Format("Mon 15:04"), Bold+pal.High))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// joinColumns places right beside left, padding each left line to the
+// widest one so the right column lines up regardless of color codes.
+func joinColumns(left, right string, gap int) string {
+	leftLines := strings.Split(left, "\n")
+	rightLines := strings.Split(right, "\n")
+	width := blockWidth(left) + gap
+
+	n := max(len(leftLines), len(rightLines))
+	lines := make([]string, n)
+	for i := range lines {
+		l, r := "", ""
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+		if r == "" {
+			lines[i] = strings.TrimRight(l, " ")
+			continue
+		}
+		lines[i] = l + strings.Repeat(" ", width-VisibleWidth(l)) + r
+	}
+	return strings.Join(lines, "\n")
+}
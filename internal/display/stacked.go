@@ -0,0 +1,103 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Segment is one part of a stacked progress bar.
+type Segment struct {
+	Label string  // Legend label, e.g. "Sonnet"
+	Value float64 // Amount in the bar's unit
+	Color string  // ANSI color code (ignored with NoColor)
+}
+
+// segmentBlocks tell segments apart when colors are disabled, in segment order.
+var segmentBlocks = []string{FillBlock, "▓", "▒", "▞", "▚"}
+
+// segmentBlock returns the fill character for the i-th segment.
+func (p *ProgressBar) segmentBlock(i int) string {
+	if !p.NoColor {
+		return FillBlock
+	}
+	return segmentBlocks[i%len(segmentBlocks)]
+}
+
+// segmentColor returns the color for the i-th segment, falling back to the
+// bar color.
+func (p *ProgressBar) segmentColor(i int) string {
+	pal := p.palette()
+	if c := p.Segments[i].Color; c != "" {
+		return c
+	}
+	if p.BarColor != "" {
+		return p.BarColor
+	}
+	return pal.Accent
+}
+
+// segmentTotal returns the sum of all segment values, ignoring negative ones.
+func (p *ProgressBar) segmentTotal() float64 {
+	total := 0.0
+	for _, s := range p.Segments {
+		total += max(s.Value, 0)
+	}
+	return total
+}
+
+// segmentCells splits filledCount cells between the segments in proportion to
+// their values, using largest remainders so the cells add up exactly.
+func (p *ProgressBar) segmentCells(filledCount int) []int {
+	cells := make([]int, len(p.Segments))
+	total := p.segmentTotal()
+	if total <= 0 || filledCount <= 0 {
+		return cells
+	}
+
+	type remainder struct {
+		index int
+		frac  float64
+	}
+	remainders := make([]remainder, len(p.Segments))
+	assigned := 0
+	for i, s := range p.Segments {
+		exact := float64(filledCount) * max(s.Value, 0) / total
+		cells[i] = int(exact)
+		assigned += cells[i]
+		remainders[i] = remainder{i, exact - math.Floor(exact)}
+	}
+	sort.SliceStable(remainders, func(a, b int) bool {
+		return remainders[a].frac > remainders[b].frac
+	})
+	for _, r := range remainders[:filledCount-assigned] {
+		cells[r.index]++
+	}
+	return cells
+}
+
+// Legend returns a single line naming each segment with its fill and value,
+// e.g. "█ Sonnet 12.3h  █ Opus 4.0h". It is empty for plain bars.
+func (p *ProgressBar) Legend() string {
+	pal := p.palette()
+	if len(p.Segments) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(p.Segments))
+	for i, s := range p.Segments {
+		value := fmt.Sprintf("%.1f%s", s.Value, p.Unit)
+		if p.ShowCost {
+			value = fmt.Sprintf("%s%.2f", p.CostPrefix, s.Value)
+		}
+		block := p.segmentBlock(i)
+		if p.NoColor {
+			parts[i] = block + " " + s.Label + " " + value
+		} else {
+			parts[i] = p.segmentColor(i) + block + Reset + " " + pal.Dim + s.Label + " " + value + Reset
+		}
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,50 @@
+package display
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSegmentCells(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		filled int
+		want   []int
+	}{
+		{"proportional", []float64{3, 1}, 8, []int{6, 2}},
+		{"largest remainder", []float64{1, 1, 1}, 10, []int{4, 3, 3}},
+		{"remainder to bigger fraction", []float64{2, 5}, 4, []int{1, 3}},
+		{"zero total", []float64{0, 0}, 5, []int{0, 0}},
+		{"no filled cells", []float64{2, 3}, 0, []int{0, 0}},
+		{"negative value", []float64{5, -2}, 10, []int{10, 0}},
+		{"all negative", []float64{-1, -3}, 4, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ProgressBar{}
+			for _, v := range tt.values {
+				p.Segments = append(p.Segments, Segment{Value: v})
+			}
+			got := p.segmentCells(tt.filled)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("segmentCells(%d) = %v, want %v", tt.filled, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderNegativeSegment(t *testing.T) {
+	p := &ProgressBar{Width: 12, Total: 10, NoColor: true, Segments: []Segment{{Value: 5}, {Value: -2}}}
+	out := p.Render()
+	if p.Current != 0 {
+		t.Errorf("Render changed Current to %v", p.Current)
+	}
+	if !strings.Contains(out, "50.0%") {
+		t.Errorf("Render = %q, want 50.0%% of negative-free total", out)
+	}
+	if again := p.Render(); again != out {
+		t.Errorf("second Render = %q, want %q", again, out)
+	}
+}
@@ -0,0 +1,106 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Compiled terminfo magic numbers, for 16-bit and 32-bit numeric capabilities.
+const (
+	terminfoMagic   = 0o432
+	terminfoMagic32 = 0o1036
+)
+
+// terminfoMaxColors is the index of the max_colors numeric capability.
+const terminfoMaxColors = 13
+
+// terminfoDirs returns the directories searched for compiled terminfo
+// entries, in ncurses order.
+func terminfoDirs() []string {
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	if list := os.Getenv("TERMINFO_DIRS"); list != "" {
+		for _, dir := range strings.Split(list, ":") {
+			if dir == "" {
+				dir = "/usr/share/terminfo" // An empty entry means the system default
+			}
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo")
+}
+
+// terminfoColors returns the max_colors capability of a terminal, or 0 when
+// its terminfo entry cannot be found or does not define it.
+func terminfoColors(term string) int {
+	if term == "" || strings.ContainsAny(term, "/\\") {
+		return 0
+	}
+	for _, dir := range terminfoDirs() {
+		// Entries live under their first letter, or its hex code on macOS
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			data, err := os.ReadFile(filepath.Join(dir, sub, term))
+			if err != nil {
+				continue
+			}
+			if n, ok := parseTerminfoColors(data); ok {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// parseTerminfoColors extracts max_colors from a compiled terminfo entry.
+func parseTerminfoColors(data []byte) (int, bool) {
+	if len(data) < 12 {
+		return 0, false
+	}
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	magic, namesSize, boolCount, numCount := header[0], header[1], header[2], header[3]
+
+	numSize := 2
+	switch magic {
+	case terminfoMagic:
+	case terminfoMagic32:
+		numSize = 4
+	default:
+		return 0, false
+	}
+	if numCount <= terminfoMaxColors {
+		return 0, false
+	}
+
+	// Numbers start on an even offset after the names and booleans
+	offset := 12 + namesSize + boolCount
+	if offset%2 != 0 {
+		offset++
+	}
+	offset += terminfoMaxColors * numSize
+	if offset+numSize > len(data) {
+		return 0, false
+	}
+
+	var n int
+	if numSize == 4 {
+		n = int(int32(binary.LittleEndian.Uint32(data[offset:])))
+	} else {
+		n = int(int16(binary.LittleEndian.Uint16(data[offset:])))
+	}
+	if n < 0 {
+		return 0, false // Capability absent or cancelled
+	}
+	return n, true
+}
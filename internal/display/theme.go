@@ -0,0 +1,226 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Theme assigns hex colors ("#RRGGBB", or empty for the terminal default)
+// to each role in the display.
+type Theme struct {
+	Name   string
+	Header string // Figlet header and table titles
+	Bar    string // Progress bar fill and highlighted values
+	Border string // Box drawing and rules
+	Text   string // Labels and table headers
+	Dim    string // Secondary text
+	Muted  string // Empty bar cells and notes
+	Low    string // Usage below the first threshold
+	Medium string // Usage between the thresholds
+	High   string // Usage above the second threshold
+}
+
+// Palette holds a theme's role colors as ANSI codes, with the thresholds at
+// which usage switches between the low, medium and high colors.
+type Palette struct {
+	Header, Accent, Border, Text, Dim, Muted string
+	Low, Medium, High                        string
+	Thresholds                               Thresholds
+}
+
+// Thresholds are the usage percentages at which usage turns medium and high.
+type Thresholds struct {
+	Medium, High float64
+}
+
+// DefaultThresholds are used when none are configured.
+var DefaultThresholds = Thresholds{Medium: 50, High: 75}
+
+// Palette returns the theme's colors as ANSI codes with the given thresholds.
+func (t Theme) Palette(th Thresholds) Palette {
+	return Palette{
+		Header:     HexColor(t.Header),
+		Accent:     HexColor(t.Bar),
+		Border:     HexColor(t.Border),
+		Text:       HexColor(t.Text),
+		Dim:        HexColor(t.Dim),
+		Muted:      HexColor(t.Muted),
+		Low:        HexColor(t.Low),
+		Medium:     HexColor(t.Medium),
+		High:       HexColor(t.High),
+		Thresholds: th,
+	}
+}
+
+// Usage returns the low, medium or high color for a usage percentage,
+// switching at the thresholds.
+func (p Palette) Usage(percentage float64) string {
+	switch {
+	case percentage < p.Thresholds.Medium:
+		return p.Low
+	case percentage < p.Thresholds.High:
+		return p.Medium
+	default:
+		return p.High
+	}
+}
+
+// DefaultTheme is the theme used when none is configured.
+const DefaultTheme = "claude"
+
+// Themes holds the built-in themes by name. Themes defined in config are
+// kept by the caller and passed to LookupTheme and ParseThemeSpec.
+var Themes = map[string]Theme{
+	"claude": {
+		Header: "#CC5500", Bar: "#CC5500", Border: "#64748B",
+		Text: "#FFFFFF", Dim: "#D1D5DB", Muted: "#9CA3AF",
+		Low: "#22C55E", Medium: "#EAB308", High: "#EF4444",
+	},
+	"high-contrast": {
+		Header: "#FFFF00", Bar: "#FFFFFF", Border: "#FFFFFF",
+		Text: "#FFFFFF", Dim: "#FFFFFF", Muted: "#808080",
+		Low: "#00FF00", Medium: "#FFFF00", High: "#FF0000",
+	},
+	"solarized": {
+		Header: "#CB4B16", Bar: "#268BD2", Border: "#586E75",
+		Text: "#EEE8D5", Dim: "#93A1A1", Muted: "#657B83",
+		Low: "#859900", Medium: "#B58900", High: "#DC322F",
+	},
+	// Okabe-Ito palette, distinguishable with common color vision deficiencies
+	"colorblind": {
+		Header: "#E69F00", Bar: "#0072B2", Border: "#999999",
+		Text: "#FFFFFF", Dim: "#D9D9D9", Muted: "#999999",
+		Low: "#56B4E9", Medium: "#E69F00", High: "#D55E00",
+	},
+	"monochrome": {},
+}
+
+// themeRoles maps theme spec keys to their fields.
+var themeRoles = map[string]func(t *Theme) *string{
+	"header": func(t *Theme) *string { return &t.Header },
+	"bar":    func(t *Theme) *string { return &t.Bar },
+	"border": func(t *Theme) *string { return &t.Border },
+	"text":   func(t *Theme) *string { return &t.Text },
+	"dim":    func(t *Theme) *string { return &t.Dim },
+	"muted":  func(t *Theme) *string { return &t.Muted },
+	"low":    func(t *Theme) *string { return &t.Low },
+	"medium": func(t *Theme) *string { return &t.Medium },
+	"high":   func(t *Theme) *string { return &t.High },
+}
+
+// ThemeNames returns the built-in and custom theme names in sorted order.
+func ThemeNames(custom map[string]Theme) []string {
+	names := make([]string, 0, len(Themes)+len(custom))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	for name := range custom {
+		if _, ok := Themes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns the named theme from custom, the themes defined in
+// config, or the built-in themes.
+func LookupTheme(name string, custom map[string]Theme) (Theme, error) {
+	name = strings.ToLower(name)
+	if t, ok := custom[name]; ok {
+		return t, nil
+	}
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(custom), ", "))
+}
+
+// ParseThemeSpec builds a theme from a configuration spec of comma separated
+// key=value pairs, for example:
+//
+//	base=solarized,header=#FF8800,bar=#00AAFF
+//
+// Keys are the role names (header, bar, border, text, dim, muted, low,
+// medium, high). Unset roles come from base, which defaults to the existing
+// theme of the same name or the default theme. Bases are looked up in custom
+// before the built-in themes.
+func ParseThemeSpec(name, spec string, custom map[string]Theme) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Theme{}, fmt.Errorf("invalid theme name %q", name)
+	}
+
+	base := DefaultTheme
+	if _, err := LookupTheme(name, custom); err == nil {
+		base = name
+	}
+	fields := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return Theme{}, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if key == "base" {
+			base = strings.ToLower(value)
+			continue
+		}
+		fields[key] = value
+	}
+
+	t, err := LookupTheme(base, custom)
+	if err != nil {
+		return Theme{}, fmt.Errorf("unknown base theme %q", base)
+	}
+	t.Name = name
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		role, ok := themeRoles[key]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown key %q", key)
+		}
+		value := fields[key]
+		if value != "" && HexColor(value) == "" {
+			return Theme{}, fmt.Errorf("%s: expected a #RRGGBB color, got %q", key, value)
+		}
+		*role(&t) = value
+	}
+	return t, nil
+}
+
+// ParseThresholds parses "medium,high" usage percentages such as "60,85".
+// An empty spec returns DefaultThresholds.
+func ParseThresholds(spec string) (Thresholds, error) {
+	if strings.TrimSpace(spec) == "" {
+		return DefaultThresholds, nil
+	}
+	parts := strings.Split(spec, ",")
+	if len(parts) != 2 {
+		return Thresholds{}, fmt.Errorf("expected two percentages, got %q", spec)
+	}
+	medium, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Thresholds{}, fmt.Errorf("invalid percentage %q", parts[0])
+	}
+	high, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Thresholds{}, fmt.Errorf("invalid percentage %q", parts[1])
+	}
+	if medium < 0 || high < medium {
+		return Thresholds{}, fmt.Errorf("thresholds must satisfy 0 <= medium <= high, got %q", spec)
+	}
+	return Thresholds{Medium: medium, High: high}, nil
+}
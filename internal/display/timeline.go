@@ -0,0 +1,201 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// Timeline label columns.
+const (
+	timelineProjectWidth = 16
+	timelineModelWidth   = 11
+	timelineLabelWidth   = 2 + timelineProjectWidth + 1 + timelineModelWidth + 1
+)
+
+// Timeline widths in columns for the whole week.
+const (
+	MinTimelineWidth     = 56  // 8 columns per day, room for "Mon 13"
+	MaxTimelineWidth     = 168 // One column per hour
+	defaultTimelineWidth = 84
+)
+
+// TimelineWidth returns the number of time columns that fit a terminal
+// termWidth columns wide, a whole number per day, or the default when the
+// width is unknown.
+func TimelineWidth(termWidth int) int {
+	if termWidth <= 0 {
+		return defaultTimelineWidth
+	}
+	width := (termWidth - timelineLabelWidth - 2) / 7 * 7
+	return min(max(width, MinTimelineWidth), MaxTimelineWidth)
+}
+
+// timelineGlyphs are the characters of a timeline, in Unicode and ASCII.
+type timelineGlyphs struct {
+	fill, day, now, reset, blockA, blockB string
+}
+
+var (
+	unicodeTimeline = timelineGlyphs{fill: FillBlock, day: "┆", now: "│", reset: "┃", blockA: "━", blockB: "─"}
+	asciiTimeline   = timelineGlyphs{fill: "#", day: ":", now: "|", reset: "!", blockA: "=", blockB: "-"}
+)
+
+// RenderTimeline draws each session started in the current week as a bar
+// across the days, one row per session so concurrent sessions never share a
+// row. Sessions are picked like Tracker picks them, so the totals match the
+// main display.
+// A strip above the sessions marks the 5-hour blocks, and the weekly reset
+// and the current time are marked on every row. cols is the number of time
+// columns for the whole week.
+func (o *Output) RenderTimeline(sessions []*claude.SessionData, usage *claude.UsageData, cols int) string {
+	pal := o.palette()
+	cols = max(cols, 7)
+	glyphs := unicodeTimeline
+	if o.ASCII {
+		glyphs = asciiTimeline
+	}
+
+	now := usage.LastUpdated
+	weekStart := usage.WeeklyStartTime
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	reset := now.Add(usage.WeeklyResetIn)
+	span := weekEnd.Sub(weekStart)
+	column := func(t time.Time) int {
+		c := int(float64(t.Sub(weekStart)) / float64(span) * float64(cols))
+		return min(max(c, 0), cols-1)
+	}
+	nowCol := column(now)
+	resetCol := cols // Right edge unless a limit message moved the reset
+	if reset.Before(weekEnd) {
+		resetCol = column(reset)
+	}
+
+	var week []*claude.SessionData
+	for _, s := range sessions {
+		if !s.StartTime.Before(weekStart) && s.StartTime.Before(weekEnd) {
+			week = append(week, s)
+		}
+	}
+	sort.Slice(week, func(i, j int) bool { return week[i].StartTime.Before(week[j].StartTime) })
+
+	var sb strings.Builder
+	title := fmt.Sprintf("Sessions this week, %s – %s",
+		weekStart.Format("Mon Jan 2"), weekEnd.Add(-time.Nanosecond).Format("Mon Jan 2"))
+	sb.WriteString(o.color("  "+title, Bold+pal.Header))
+	sb.WriteString("\n\n")
+	if len(week) == 0 {
+		sb.WriteString(o.color("  No sessions this week.", pal.Muted))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	// Day columns start at each midnight
+	dayCols := make(map[int]bool)
+	days := []rune(strings.Repeat(" ", cols))
+	for d := 0; d < 7; d++ {
+		day := weekStart.AddDate(0, 0, d)
+		c := column(day)
+		dayCols[c] = true
+		copy(days[c:], []rune(day.Format("Mon 2")))
+	}
+	sb.WriteString(strings.Repeat(" ", timelineLabelWidth))
+	sb.WriteString(o.color(strings.TrimRight(string(days), " "), pal.Text))
+	sb.WriteString("\n")
+
+	// 5-hour blocks, alternating so each boundary shows
+	sb.WriteString(o.color(fmt.Sprintf("  %-*s ", timelineProjectWidth+1+timelineModelWidth, "5h blocks"), pal.Dim))
+	current := claude.CycleStart(now)
+	for c := 0; c < cols; c++ {
+		mid := weekStart.Add(time.Duration((float64(c) + 0.5) / float64(cols) * float64(span)))
+		block := claude.CycleStart(mid)
+		cell := glyphs.blockA
+		if block.Unix()/(5*3600)%2 == 1 {
+			cell = glyphs.blockB
+		}
+		color := pal.Border
+		if block.Equal(current) {
+			color = pal.Accent
+		}
+		sb.WriteString(o.color(cell, color))
+	}
+	sb.WriteString(o.timelineEdge(resetCol, cols, glyphs))
+	sb.WriteString("\n")
+
+	// One row per session
+	var projects []string
+	seen := make(map[string]bool)
+	for _, s := range week {
+		if !seen[s.Project] {
+			seen[s.Project] = true
+			projects = append(projects, s.Project)
+		}
+	}
+	names := claude.ShortProjectNames(projects)
+	summed := 0.0
+	var spans []claude.Span
+	for _, s := range week {
+		model := o.Models.Normalize(claude.PrimaryModel(s.Models))
+		if len(s.Models) == 0 {
+			model = claude.ModelInfo{Family: claude.FamilySonnet, Display: o.Models.Display(claude.FamilySonnet)}
+		}
+		label := fmt.Sprintf("  %s %s ",
+			fit(names[s.Project], timelineProjectWidth), fit(model.Display, timelineModelWidth))
+		sb.WriteString(o.color(label, pal.Dim))
+
+		from, to := column(s.StartTime), column(s.EndTime)
+		fill := o.familyColor(model.Family)
+		for c := 0; c < cols; c++ {
+			switch {
+			case c == from:
+				sb.WriteString(o.color(strings.Repeat(glyphs.fill, to-from+1), fill))
+				c = to
+			case c == resetCol:
+				sb.WriteString(o.color(glyphs.reset, pal.High))
+			case c == nowCol:
+				sb.WriteString(o.color(glyphs.now, pal.Accent))
+			case dayCols[c]:
+				sb.WriteString(o.color(glyphs.day, pal.Muted))
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(o.timelineEdge(resetCol, cols, glyphs))
+		sb.WriteString("\n")
+		summed += s.DurationHours
+		spans = append(spans, o.Models.NewSpan(s.StartTime, s.EndTime, s.Models))
+	}
+	union := 0.0
+	for _, hours := range claude.UnionHours(spans) {
+		union += hours
+	}
+
+	// Key and summary
+	sb.WriteString("\n")
+	key := fmt.Sprintf("%s weekly reset %s (in %s)   %s now   %s%s 5h blocks",
+		glyphs.reset, reset.Format("Mon 15:04"), formatSpan(usage.WeeklyResetIn),
+		glyphs.now, glyphs.blockA, glyphs.blockB)
+	sb.WriteString(o.color("  "+key, pal.Dim))
+	sb.WriteString("\n")
+	sessionsLabel := "sessions"
+	if len(week) == 1 {
+		sessionsLabel = "session"
+	}
+	sb.WriteString(o.color(fmt.Sprintf("  %d %s · %.1fh summed · %.1fh union", len(week), sessionsLabel, summed, union), pal.Text))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// timelineEdge draws the weekly reset marker after the last column when the
+// reset falls at the end of the week.
+func (o *Output) timelineEdge(resetCol, cols int, glyphs timelineGlyphs) string {
+	pal := o.palette()
+	if resetCol < cols {
+		return ""
+	}
+	return o.color(glyphs.reset, pal.High)
+}
@@ -0,0 +1,373 @@
+// Package display handles terminal output formatting with colors and progress bars.
+package display
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/report"
+)
+
+// Widget is one block of the full display, drawn by its type with the given
+// options.
+type Widget struct {
+	Name    string            // Name used in the widget list
+	Type    string            // Built-in widget type (see WidgetTypes)
+	Options map[string]string // Type-specific options
+}
+
+// widgetType draws a widget. It returns "" when there is nothing to show,
+// in which case the widget takes no space.
+type widgetType struct {
+	options []string // Accepted option keys
+	render  func(o *Output, w Widget, usage *claude.UsageData) string
+}
+
+// WidgetTypes lists the built-in widget types by name.
+var WidgetTypes = map[string]widgetType{
+	"header":     {options: []string{"text"}, render: (*Output).headerWidget},
+	"stats":      {render: func(o *Output, _ Widget, usage *claude.UsageData) string { return o.renderModelStats(usage) }},
+	"burn":       {render: func(o *Output, _ Widget, usage *claude.UsageData) string { return o.renderBurnRate(usage) }},
+	"notice":     {render: (*Output).noticeWidget},
+	"weekly-bar": {options: []string{"pace"}, render: (*Output).weeklyBarWidget},
+	"cycle-bar":  {render: (*Output).cycleBarWidget},
+	"model-bars": {render: (*Output).modelBarsWidget},
+	"projects":   {options: []string{"top"}, render: (*Output).projectsWidget},
+	"resets":     {render: (*Output).resetsWidget},
+	"text":       {options: []string{"text", "color"}, render: (*Output).textWidget},
+	"sparkline":  {options: []string{"chart"}, render: (*Output).sparklineWidget},
+}
+
+// DefaultWidgets is the widget list of the full display when none is
+// configured.
+const DefaultWidgets = "header,stats,burn,notice,weekly-bar"
+
+// WidgetTypeNames returns the built-in widget types in sorted order.
+func WidgetTypeNames() []string {
+	names := make([]string, 0, len(WidgetTypes))
+	for name := range WidgetTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseWidgetSpec parses a widget definition such as
+// "type=projects,top=5". The type defaults to name when that is a built-in
+// type, so built-in widgets can be given options under their own name. A
+// text option takes the rest of the spec, commas included.
+func ParseWidgetSpec(name, spec string) (Widget, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Widget{}, fmt.Errorf("invalid widget name %q", name)
+	}
+
+	w := Widget{Name: name, Type: name, Options: make(map[string]string)}
+	rest := spec
+	for rest != "" {
+		var pair string
+		pair, rest, _ = strings.Cut(rest, ",")
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return Widget{}, fmt.Errorf("expected key=value, got %q", pair)
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if key == "text" && rest != "" {
+			value += "," + rest
+			rest = ""
+		}
+		if key == "type" {
+			w.Type = strings.ToLower(value)
+			continue
+		}
+		w.Options[key] = value
+	}
+
+	t, ok := WidgetTypes[w.Type]
+	if !ok {
+		return Widget{}, fmt.Errorf("unknown widget type %q (available: %s)", w.Type, strings.Join(WidgetTypeNames(), ", "))
+	}
+	for key, value := range w.Options {
+		if !slices.Contains(t.options, key) {
+			return Widget{}, fmt.Errorf("%s: unknown option %q", w.Type, key)
+		}
+		if err := validateWidgetOption(key, value); err != nil {
+			return Widget{}, fmt.Errorf("%s: %v", key, err)
+		}
+	}
+	return w, nil
+}
+
+// validateWidgetOption checks option values that are not free text.
+func validateWidgetOption(key, value string) error {
+	switch key {
+	case "top":
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("expected a positive number, got %q", value)
+		}
+	case "pace":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+	case "chart":
+		if _, err := report.ParseChart(value); err != nil {
+			return err
+		}
+	case "color":
+		if _, ok := themeRoles[strings.ToLower(value)]; !ok && HexColor(value) == "" {
+			return fmt.Errorf("expected a theme role or #RRGGBB color, got %q", value)
+		}
+	}
+	return nil
+}
+
+// ParseWidgets resolves a comma-separated widget list against the custom
+// widgets defined in config, by name, and the built-in types.
+func ParseWidgets(list string, custom map[string]Widget) ([]Widget, error) {
+	var widgets []Widget
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if w, ok := custom[name]; ok {
+			widgets = append(widgets, w)
+			continue
+		}
+		if _, ok := WidgetTypes[name]; !ok {
+			return nil, fmt.Errorf("unknown widget %q (available: %s)", name, strings.Join(WidgetTypeNames(), ", "))
+		}
+		widgets = append(widgets, Widget{Name: name, Type: name})
+	}
+	if len(widgets) == 0 {
+		return nil, fmt.Errorf("no widgets in %q", list)
+	}
+	return widgets, nil
+}
+
+// renderWidgets draws the widgets in order, separated by blank lines.
+func (o *Output) renderWidgets(usage *claude.UsageData) string {
+	widgets := o.Widgets
+	if len(widgets) == 0 {
+		widgets, _ = ParseWidgets(DefaultWidgets, nil)
+	}
+
+	var blocks []string
+	for _, w := range widgets {
+		t, ok := WidgetTypes[w.Type]
+		if !ok {
+			continue
+		}
+		block := t.render(o, w, usage)
+		if block == "" {
+			continue
+		}
+		if o.Offset > 0 {
+			block = o.addOffset(block)
+		}
+		blocks = append(blocks, block)
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// headerWidget draws the figlet header, centered over the progress bars.
+func (o *Output) headerWidget(w Widget, usage *claude.UsageData) string {
+	text := o.HeaderText
+	if t, ok := w.Options["text"]; ok {
+		text = t
+	}
+	header := o.renderHeader(text, usage)
+	if o.TermWidth > 0 {
+		header = padLines(header, (o.Width-blockWidth(header))/2)
+	}
+	return header
+}
+
+// noticeWidget reports a reached limit, if any.
+func (o *Output) noticeWidget(_ Widget, usage *claude.UsageData) string {
+	if usage.LimitReached == nil {
+		return ""
+	}
+	return o.renderLimitNotice(usage.LimitReached)
+}
+
+// weeklyBarWidget draws the weekly hours bar, with the pace marker unless
+// pace=false.
+func (o *Output) weeklyBarWidget(w Widget, usage *claude.UsageData) string {
+	pace, err := strconv.ParseBool(w.Options["pace"])
+	if err != nil {
+		pace = true
+	}
+	return o.renderProgressBar(usage, pace)
+}
+
+// cycleBarWidget draws prompts used in the current 5-hour cycle.
+func (o *Output) cycleBarWidget(_ Widget, usage *claude.UsageData) string {
+	bar := &ProgressBar{
+		Width:       o.Width,
+		Current:     float64(usage.CyclePrompts),
+		Total:       float64(usage.Tier.Cycle5hMax),
+		TimeLeft:    claude.FormatResetTime(usage.CycleResetIn),
+		Unit:        " prompts",
+		ValueFormat: "%.0f",
+		NoColor:     o.NoColor,
+		Palette:     o.palette(),
+	}
+	return bar.Render()
+}
+
+// modelBarsWidget draws a weekly bar for each model family with a cap.
+func (o *Output) modelBarsWidget(_ Widget, usage *claude.UsageData) string {
+	type familyBar struct {
+		family     string
+		hours, max float64
+	}
+	bars := []familyBar{{claude.FamilySonnet, usage.WeeklySonnetHours, usage.Tier.WeeklySonnetMax}}
+	if usage.Tier.HasOpus() {
+		bars = append(bars, familyBar{claude.FamilyOpus, usage.WeeklyOpusHours, usage.Tier.WeeklyOpusMax})
+	}
+	for _, family := range usage.Tier.ModelLimitFamilies() {
+		bars = append(bars, familyBar{family, usage.FamilyHours(family), usage.Tier.ModelLimits[family].Max})
+	}
+
+	timeLeft := claude.FormatResetTime(usage.WeeklyResetIn)
+	blocks := make([]string, 0, len(bars))
+	for _, b := range bars {
+		color := HexColor(o.Models.Color(b.family))
+		bar := &ProgressBar{
+			Width:    o.Width,
+			Current:  b.hours,
+			Total:    b.max,
+			TimeLeft: timeLeft,
+			Unit:     "h",
+			NoColor:  o.NoColor,
+			BarColor: color,
+			Palette:  o.palette(),
+		}
+		label := " " + o.Models.Display(b.family)
+		if b.family != claude.FamilySonnet && b.family != claude.FamilyOpus {
+			label += o.withinNote(b.family)
+		}
+		if !o.NoColor {
+			label = Bold + color + label + Reset
+		}
+		blocks = append(blocks, label+"\n"+bar.Render())
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// defaultTopProjects is how many projects the projects widget lists.
+const defaultTopProjects = 3
+
+// projectsWidget lists the heaviest projects this week with their share of
+// the weekly hours.
+func (o *Output) projectsWidget(w Widget, usage *claude.UsageData) string {
+	pal := o.palette()
+	if len(usage.Projects) == 0 {
+		return ""
+	}
+	top, err := strconv.Atoi(w.Options["top"])
+	if err != nil || top < 1 {
+		top = defaultTopProjects
+	}
+
+	var sb strings.Builder
+	indent := "    "
+	sb.WriteString(o.color(indent+"Top projects", pal.Text))
+	total := usage.SummedWeeklyHours() // Project hours are summed in either mode
+	for i, p := range usage.Projects {
+		if i == top {
+			break
+		}
+		share := 0.0
+		if total > 0 {
+			share = p.TotalHours() / total * 100
+		}
+		sb.WriteString("\n")
+		sb.WriteString(o.color(fmt.Sprintf("%s  %s %6.1fh %5.1f%% ", indent, fit(p.Name, 18), p.TotalHours(), share), pal.Dim))
+		sb.WriteString(o.shareBar(share))
+	}
+	return sb.String()
+}
+
+// resetsWidget counts down to the 5-hour and weekly resets.
+func (o *Output) resetsWidget(_ Widget, usage *claude.UsageData) string {
+	pal := o.palette()
+	now := usage.LastUpdated
+	line := func(label string, in time.Duration, exact bool) string {
+		at := now.Add(in).Local().Format("Mon 15:04")
+		if !exact {
+			at = "~" + at
+		}
+		if o.NoColor {
+			return fmt.Sprintf("    %-8sresets in %s (%s)", label+":", formatSpan(in), at)
+		}
+		return "    " + pal.Text + fmt.Sprintf("%-8s", label+":") + Reset +
+			pal.Dim + "resets in " + Reset + pal.Accent + formatSpan(in) + Reset +
+			pal.Dim + " (" + at + ")" + Reset
+	}
+	return line("5h", usage.CycleResetIn, usage.CycleResetExact) + "\n" +
+		line("Weekly", usage.WeeklyResetIn, usage.WeeklyResetExact)
+}
+
+// UsesWidget reports whether the widget list includes a widget of the type.
+func (o *Output) UsesWidget(widgetType string) bool {
+	for _, w := range o.Widgets {
+		if w.Type == widgetType {
+			return true
+		}
+	}
+	return false
+}
+
+// sparklineWidget shows recent activity as a sparkline, prompts per hour
+// over the last day unless chart=daily.
+func (o *Output) sparklineWidget(w Widget, usage *claude.UsageData) string {
+	pal := o.palette()
+	if o.History == nil {
+		return ""
+	}
+	chart, err := report.ParseChart(w.Options["chart"])
+	if err != nil {
+		chart = report.ChartHourly
+	}
+	s := report.Activity(o.History, chart, usage.LastUpdated, o.Models)
+	peak := s.Max()
+	if peak == 0 {
+		return ""
+	}
+	per := "/h"
+	if chart == report.ChartDaily {
+		per = "/day"
+	}
+	return o.color("    "+s.Title, pal.Text) + "\n" +
+		"    " + o.Sparkline(s) + o.color("  peak "+formatChartValue(peak, s.Unit)+per, pal.Dim)
+}
+
+// textWidget shows a line of custom text, in a theme role or hex color.
+func (o *Output) textWidget(w Widget, _ *claude.UsageData) string {
+	pal := o.palette()
+	text := w.Options["text"]
+	if text == "" {
+		return ""
+	}
+	color := pal.Text
+	if c := w.Options["color"]; c != "" {
+		if role, ok := themeRoles[strings.ToLower(c)]; ok {
+			theme := o.Theme
+			color = HexColor(*role(&theme))
+		} else {
+			color = HexColor(c)
+		}
+	}
+	return o.color("    "+text, color)
+}
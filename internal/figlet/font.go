@@ -0,0 +1,238 @@
+// Package figlet provides ASCII art text rendering with FIGlet fonts.
+package figlet
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed fonts/*.flf
+var embedded embed.FS
+
+// DefaultFont is the embedded font used when none is configured.
+const DefaultFont = "small"
+
+// Font is a parsed FIGlet font.
+type Font struct {
+	Name       string
+	Hardblank  rune // Rendered as a space, but never smushed away
+	Height     int  // Lines per character
+	Baseline   int  // Lines from the top to the baseline
+	OldLayout  int  // Legacy layout parameter (-1 full width, 0 kerning, >0 smush rules)
+	FullLayout int  // Full layout parameter; derived from OldLayout when absent
+	Chars      map[rune][]string
+}
+
+// deutschChars are the required characters that follow ASCII 32-126.
+var deutschChars = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// Parse reads a FIGlet font in .flf format: a header line, comment lines,
+// the required characters 32-126 and the seven Deutsch characters, followed
+// by any number of code-tagged characters.
+func Parse(name string, r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("%s: empty font file", name)
+	}
+	font, comments, err := parseHeader(name, scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < comments; i++ {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("%s: truncated comment block", name)
+		}
+	}
+
+	required := make([]rune, 0, 95+len(deutschChars))
+	for c := rune(32); c <= 126; c++ {
+		required = append(required, c)
+	}
+	required = append(required, deutschChars...)
+
+	for _, c := range required {
+		glyph, ok := readGlyph(scanner, font.Height)
+		if !ok {
+			// Fonts may stop early; the missing characters are simply absent
+			return font, scanner.Err()
+		}
+		font.Chars[c] = glyph
+	}
+
+	// Code-tagged characters: a line with the code, then the glyph
+	for scanner.Scan() {
+		tag := strings.TrimSpace(scanner.Text())
+		if tag == "" {
+			continue
+		}
+		code, err := parseCode(strings.Fields(tag)[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid character code %q", name, tag)
+		}
+		glyph, ok := readGlyph(scanner, font.Height)
+		if !ok {
+			return nil, fmt.Errorf("%s: truncated character %d", name, code)
+		}
+		if code != -1 { // -1 is reserved and never defined
+			font.Chars[rune(code)] = glyph
+		}
+	}
+	return font, scanner.Err()
+}
+
+// parseHeader parses the "flf2a" header line, returning the font and the
+// number of comment lines that follow.
+func parseHeader(name, line string) (*Font, int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 6 || !strings.HasPrefix(fields[0], "flf2a") || len(fields[0]) < 6 {
+		return nil, 0, fmt.Errorf("%s: not a FIGlet font (bad header %q)", name, line)
+	}
+
+	nums := make([]int, 0, len(fields)-1)
+	for _, f := range fields[1:] {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			break
+		}
+		nums = append(nums, n)
+	}
+	if len(nums) < 5 || nums[0] < 1 {
+		return nil, 0, fmt.Errorf("%s: invalid header %q", name, line)
+	}
+
+	font := &Font{
+		Name:      name,
+		Hardblank: []rune(fields[0])[5],
+		Height:    nums[0],
+		Baseline:  nums[1],
+		OldLayout: nums[3],
+		Chars:     make(map[rune][]string),
+	}
+	if len(nums) >= 7 {
+		font.FullLayout = nums[6]
+	} else {
+		font.FullLayout = fullLayoutFromOld(font.OldLayout)
+	}
+	return font, nums[4], nil
+}
+
+// fullLayoutFromOld derives the full layout from the legacy parameter, as
+// FIGlet does for fonts without one: -1 is full width, 0 is kerning and
+// positive values are the horizontal smushing rules.
+func fullLayoutFromOld(old int) int {
+	switch {
+	case old < 0:
+		return 0
+	case old == 0:
+		return layoutKerning
+	}
+	return (old & 31) | layoutSmushing
+}
+
+// parseCode parses a character code in decimal, 0x hex or 0 octal form.
+func parseCode(s string) (int64, error) {
+	return strconv.ParseInt(s, 0, 32)
+}
+
+// readGlyph reads height lines of a character, stripping trailing
+// whitespace and the endmark run from each.
+func readGlyph(scanner *bufio.Scanner, height int) ([]string, bool) {
+	glyph := make([]string, height)
+	for i := range glyph {
+		if !scanner.Scan() {
+			return nil, false
+		}
+		glyph[i] = stripEndmark(scanner.Text())
+	}
+	return glyph, true
+}
+
+// stripEndmark removes trailing whitespace and then every trailing copy of
+// the last character, which is the line's endmark.
+func stripEndmark(line string) string {
+	line = strings.TrimRight(line, " \t\r\n")
+	if line == "" {
+		return line
+	}
+	runes := []rune(line)
+	end := runes[len(runes)-1]
+	i := len(runes)
+	for i > 0 && runes[i-1] == end {
+		i--
+	}
+	return string(runes[:i])
+}
+
+// fontCache holds fonts already parsed by Lookup.
+var fontCache = struct {
+	sync.Mutex
+	fonts map[string]*Font
+}{fonts: make(map[string]*Font)}
+
+// Lookup returns a font by embedded name (e.g. "standard") or by the path of
+// a .flf file, parsing it on first use.
+func Lookup(name string) (*Font, error) {
+	if name == "" {
+		name = DefaultFont
+	}
+	fontCache.Lock()
+	defer fontCache.Unlock()
+	if f, ok := fontCache.fonts[name]; ok {
+		return f, nil
+	}
+
+	var (
+		r    io.ReadCloser
+		err  error
+		base = strings.TrimSuffix(filepath.Base(name), ".flf")
+	)
+	if strings.ContainsRune(name, filepath.Separator) || strings.HasSuffix(name, ".flf") {
+		r, err = os.Open(name)
+	} else {
+		r, err = embedded.Open("fonts/" + name + ".flf")
+		if err != nil {
+			err = fmt.Errorf("unknown font %q (available: %s, or a .flf path)", name, strings.Join(FontNames(), ", "))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	f, err := Parse(base, r)
+	if err != nil {
+		return nil, err
+	}
+	fontCache.fonts[name] = f
+	return f, nil
+}
+
+// Default returns the embedded default font.
+func Default() *Font {
+	f, err := Lookup(DefaultFont)
+	if err != nil {
+		panic(err) // Embedded fonts are known to parse
+	}
+	return f
+}
+
+// FontNames returns the names of the embedded fonts in sorted order.
+func FontNames() []string {
+	entries, _ := embedded.ReadDir("fonts")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".flf"))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,58 +1,84 @@
-// Package figlet provides ASCII art text rendering using the "small" figlet font.
+// Package figlet provides ASCII art text rendering with FIGlet fonts.
 package figlet
 
 import (
 	"strings"
 )
 
-// Render converts a string into figlet ASCII art using the small font.
+// Render converts a string into figlet ASCII art using the default font.
 // Returns a multi-line string with the rendered text.
 func Render(text string) string {
-	height := FontHeight()
-	lines := make([]strings.Builder, height)
+	return Default().Render(text)
+}
 
-	for _, char := range text {
-		pattern, ok := SmallFont[char]
-		if !ok {
-			// Use space for unknown characters
-			pattern = SmallFont[' ']
-		}
+// Art is rendered text as a grid of cells.
+type Art struct {
+	Rows   [][]rune // One row per font line, hardblanks already replaced
+	Owners [][]int  // Index of the input character behind each cell
+	Glyphs int      // Number of input characters
+}
 
-		// Find the max width for this character
-		maxWidth := 0
-		for _, line := range pattern {
-			if len(line) > maxWidth {
-				maxWidth = len(line)
-			}
-		}
+// Render converts a string into ASCII art, one line per font row, fitting
+// characters together according to the font's layout mode. Characters
+// missing from the font use its character 0, which is empty unless the font
+// defines it.
+func (f *Font) Render(text string) string {
+	art := f.RenderArt(text)
+	result := make([]string, len(art.Rows))
+	for i, row := range art.Rows {
+		result[i] = string(row)
+	}
+	return strings.Join(result, "\n")
+}
+
+// RenderArt renders text like Render, keeping track of which input
+// character produced each cell so callers can color glyphs individually.
+func (f *Font) RenderArt(text string) Art {
+	l := &layout{
+		font:   f,
+		mode:   f.FullLayout,
+		rows:   make([][]rune, f.Height),
+		owners: make([][]int, f.Height),
+	}
+	n := 0
+	for _, char := range text {
+		l.add(f.glyph(char), n)
+		n++
+	}
 
-		// Add each line, padding to max width
-		for i := 0; i < height; i++ {
-			if i < len(pattern) {
-				line := pattern[i]
-				// Pad with spaces to consistent width
-				for len(line) < maxWidth {
-					line += " "
-				}
-				lines[i].WriteString(line)
-			} else {
-				// Add empty space if pattern is shorter than height
-				lines[i].WriteString(strings.Repeat(" ", maxWidth))
+	for _, row := range l.rows {
+		for i, c := range row {
+			if c == f.Hardblank {
+				row[i] = ' '
 			}
 		}
 	}
+	return Art{Rows: l.rows, Owners: l.owners, Glyphs: n}
+}
 
-	result := make([]string, height)
-	for i, line := range lines {
-		result[i] = line.String()
+// glyph returns the rows for a character, falling back to character 0.
+func (f *Font) glyph(char rune) [][]rune {
+	rows, ok := f.Chars[char]
+	if !ok {
+		rows = f.Chars[0]
+	}
+	glyph := make([][]rune, f.Height)
+	for i := range glyph {
+		if i < len(rows) {
+			glyph[i] = []rune(rows[i])
+		}
 	}
+	return glyph
+}
 
-	return strings.Join(result, "\n")
+// RenderColored renders text with ANSI color codes using the default font.
+func RenderColored(text string, colorCode string) string {
+	return Default().RenderColored(text, colorCode)
 }
 
 // RenderColored renders text with ANSI color codes.
-func RenderColored(text string, colorCode string) string {
-	rendered := Render(text)
+func (f *Font) RenderColored(text string, colorCode string) string {
+	rendered := f.Render(text)
 	if colorCode == "" {
 		return rendered
 	}
@@ -64,21 +90,16 @@ func RenderColored(text string, colorCode string) string {
 	return strings.Join(lines, "\n")
 }
 
-// GetWidth returns the total width of rendered text.
+// GetWidth returns the total width of text rendered in the default font.
 func GetWidth(text string) int {
-	if len(text) == 0 {
-		return 0
-	}
+	return Default().Width(text)
+}
 
+// Width returns the total width of rendered text.
+func (f *Font) Width(text string) int {
 	width := 0
-	for _, char := range text {
-		pattern, ok := SmallFont[char]
-		if !ok {
-			pattern = SmallFont[' ']
-		}
-		if len(pattern) > 0 {
-			width += len(pattern[0])
-		}
+	for _, line := range strings.Split(f.Render(text), "\n") {
+		width = max(width, len([]rune(line)))
 	}
 	return width
 }
@@ -1,565 +0,0 @@
-// Package figlet provides ASCII art text rendering using the "small" figlet font.
-package figlet
-
-// SmallFont defines the character patterns for figlet "small" font.
-// Each character is represented as a slice of strings (one per line).
-// The small font is 4 lines tall. All lines per character must be same width.
-var SmallFont = map[rune][]string{
-	'A': {
-		"  _   ",
-		" /_\\  ",
-		"/ _ \\ ",
-		"/_/ \\_\\",
-	},
-	'B': {
-		" ___ ",
-		"| _ )",
-		"| _ \\",
-		"|___/",
-	},
-	'C': {
-		"  ___ ",
-		" / __|",
-		"| (__ ",
-		" \\___|",
-	},
-	'D': {
-		" ___  ",
-		"|   \\ ",
-		"| |) |",
-		"|___/ ",
-	},
-	'E': {
-		" ___ ",
-		"| __|",
-		"| _| ",
-		"|___|",
-	},
-	'F': {
-		" ___ ",
-		"| __|",
-		"| _| ",
-		"|_|  ",
-	},
-	'G': {
-		"  ___ ",
-		" / __|",
-		"| (_ |",
-		" \\___|",
-	},
-	'H': {
-		" _  _ ",
-		"| || |",
-		"| __ |",
-		"|_||_|",
-	},
-	'I': {
-		" ___ ",
-		"|_ _|",
-		" | | ",
-		"|___|",
-	},
-	'J': {
-		"   _ ",
-		"  | |",
-		"_ | |",
-		" \\__/",
-	},
-	'K': {
-		" _  __",
-		"| |/ /",
-		"|   < ",
-		"|_|\\_\\",
-	},
-	'L': {
-		" _    ",
-		"| |   ",
-		"| |__ ",
-		"|____|",
-	},
-	'M': {
-		" __  __ ",
-		"|  \\/  |",
-		"| |\\/| |",
-		"|_|  |_|",
-	},
-	'N': {
-		" _  _ ",
-		"| \\| |",
-		"| .` |",
-		"|_|\\_|",
-	},
-	'O': {
-		"  ___  ",
-		" / _ \\ ",
-		"| (_) |",
-		" \\___/ ",
-	},
-	'P': {
-		" ___ ",
-		"| _ \\",
-		"|  _/",
-		"|_|  ",
-	},
-	'Q': {
-		"  ___  ",
-		" / _ \\ ",
-		"| (_) |",
-		" \\__\\_\\",
-	},
-	'R': {
-		" ___ ",
-		"| _ \\",
-		"|   /",
-		"|_|_\\",
-	},
-	'S': {
-		" ___ ",
-		"/ __|",
-		"\\__ \\",
-		"|___/",
-	},
-	'T': {
-		" _____ ",
-		"|_   _|",
-		"  | |  ",
-		"  |_|  ",
-	},
-	'U': {
-		" _   _ ",
-		"| | | |",
-		"| |_| |",
-		" \\___/ ",
-	},
-	'V': {
-		"__   __",
-		"\\ \\ / /",
-		" \\ V / ",
-		"  \\_/  ",
-	},
-	'W': {
-		"__      __",
-		"\\ \\    / /",
-		" \\ \\/\\/ / ",
-		"  \\_/\\_/  ",
-	},
-	'X': {
-		"__  __",
-		"\\ \\/ /",
-		" >  < ",
-		"/_/\\_\\",
-	},
-	'Y': {
-		"__   __",
-		"\\ \\ / /",
-		" \\ V / ",
-		"  |_|  ",
-	},
-	'Z': {
-		" ____",
-		"|_  /",
-		" / / ",
-		"/___|",
-	},
-	'a': {
-		"      ",
-		"  __ _",
-		" / _` |",
-		" \\__,_|",
-	},
-	'b': {
-		" _    ",
-		"| |_  ",
-		"| ' \\ ",
-		"|_.__/",
-	},
-	'c': {
-		"     ",
-		"  __ ",
-		" / _|",
-		" \\___|",
-	},
-	'd': {
-		"     _",
-		"  __| |",
-		" / _` |",
-		" \\__,_|",
-	},
-	'e': {
-		"     ",
-		"  ___",
-		" / -_)",
-		" \\___|",
-	},
-	'f': {
-		"  __ ",
-		" / _|",
-		"|  _|",
-		"|_|  ",
-	},
-	'g': {
-		"       ",
-		"  __ _ ",
-		" / _` |",
-		" \\__, |",
-	},
-	'h': {
-		" _    ",
-		"| |_  ",
-		"| ' \\ ",
-		"|_||_|",
-	},
-	'i': {
-		" _ ",
-		"(_)",
-		"| |",
-		"|_|",
-	},
-	'j': {
-		"    _",
-		"   (_)",
-		"   | |",
-		"  _/ |",
-	},
-	'k': {
-		" _   ",
-		"| |__",
-		"| / /",
-		"|_\\_\\",
-	},
-	'l': {
-		" _ ",
-		"| |",
-		"| |",
-		"|_|",
-	},
-	'm': {
-		"       ",
-		" _ __  ",
-		"| '  \\ ",
-		"|_|_|_|",
-	},
-	'n': {
-		"      ",
-		" _ _  ",
-		"| ' \\ ",
-		"|_||_|",
-	},
-	'o': {
-		"      ",
-		"  ___ ",
-		" / _ \\",
-		" \\___/",
-	},
-	'p': {
-		"      ",
-		" _ __ ",
-		"| '_ \\",
-		"| .__/",
-	},
-	'q': {
-		"       ",
-		"  __ _ ",
-		" / _` |",
-		" \\__, |",
-	},
-	'r': {
-		"     ",
-		" _ _ ",
-		"| '_|",
-		"|_|  ",
-	},
-	's': {
-		"    ",
-		" ___",
-		"(_-<",
-		"/__/",
-	},
-	't': {
-		" _   ",
-		"| |_ ",
-		"|  _|",
-		" \\__|",
-	},
-	'u': {
-		"      ",
-		" _  _ ",
-		"| || |",
-		" \\_,_|",
-	},
-	'v': {
-		"      ",
-		" __ __",
-		" \\ V /",
-		"  \\_/ ",
-	},
-	'w': {
-		"         ",
-		" __ __ __",
-		" \\ V  V /",
-		"  \\_/\\_/ ",
-	},
-	'x': {
-		"     ",
-		" __ __",
-		" \\ \\ /",
-		" /_\\_\\",
-	},
-	'y': {
-		"      ",
-		" _  _ ",
-		"| || |",
-		" \\_, |",
-	},
-	'z': {
-		"    ",
-		" ___",
-		"|_ /",
-		"/__/",
-	},
-	'0': {
-		"  ___  ",
-		" / _ \\ ",
-		"| (_) |",
-		" \\___/ ",
-	},
-	'1': {
-		" _  ",
-		"/ | ",
-		"| | ",
-		"|_| ",
-	},
-	'2': {
-		" ___  ",
-		"|_  ) ",
-		" / /  ",
-		"/___|",
-	},
-	'3': {
-		" ___ ",
-		"|__ /",
-		" |_ \\",
-		"|___/",
-	},
-	'4': {
-		" _ _  ",
-		"| | | ",
-		"|_  _|",
-		"  |_| ",
-	},
-	'5': {
-		" ___ ",
-		"| __|",
-		"|__ \\",
-		"|___/",
-	},
-	'6': {
-		"  __ ",
-		" / / ",
-		"/ _ \\",
-		"\\___/",
-	},
-	'7': {
-		" ____ ",
-		"|__  |",
-		"  / / ",
-		" /_/  ",
-	},
-	'8': {
-		" ___ ",
-		"( _ )",
-		"/ _ \\",
-		"\\___/",
-	},
-	'9': {
-		" ___ ",
-		"/ _ \\",
-		"\\_, /",
-		" /_/ ",
-	},
-	' ': {
-		"    ",
-		"    ",
-		"    ",
-		"    ",
-	},
-	'.': {
-		"   ",
-		"   ",
-		" _ ",
-		"(_)",
-	},
-	',': {
-		"   ",
-		"   ",
-		" _ ",
-		"( )",
-	},
-	':': {
-		" _ ",
-		"(_)",
-		" _ ",
-		"(_)",
-	},
-	'-': {
-		"     ",
-		" ____",
-		"|____|",
-		"     ",
-	},
-	'_': {
-		"      ",
-		"      ",
-		"      ",
-		"______|",
-	},
-	'/': {
-		"    __",
-		"   / /",
-		"  / / ",
-		" /_/  ",
-	},
-	'!': {
-		" _ ",
-		"| |",
-		"|_|",
-		"(_)",
-	},
-	'?': {
-		" ___ ",
-		"|__ \\",
-		"  /_/",
-		" (_) ",
-	},
-	'\'': {
-		" _ ",
-		"( )",
-		"|/ ",
-		"   ",
-	},
-	'"': {
-		" _ _ ",
-		"( | )",
-		" V V ",
-		"     ",
-	},
-	'(': {
-		"  __",
-		" / /",
-		"| | ",
-		" \\_\\",
-	},
-	')': {
-		"__  ",
-		"\\ \\ ",
-		" | |",
-		"/_/ ",
-	},
-	'[': {
-		" __ ",
-		"| _|",
-		"| | ",
-		"|__|",
-	},
-	']': {
-		" __ ",
-		"|_ |",
-		" | |",
-		"|__|",
-	},
-	'@': {
-		"  ____  ",
-		" / __ \\ ",
-		"| |__| |",
-		" \\____/ ",
-	},
-	'#': {
-		"  _  _  ",
-		" | || | ",
-		"_| || |_",
-		"|_  __| ",
-	},
-	'$': {
-		" _|_ ",
-		"/ __|",
-		"\\__ \\",
-		"|___/",
-	},
-	'%': {
-		"  __",
-		"(/ /",
-		" / /",
-		"/_/ ",
-	},
-	'^': {
-		" /\\ ",
-		"|/\\|",
-		"    ",
-		"    ",
-	},
-	'&': {
-		" ___   ",
-		"( _ )  ",
-		"/ _ \\/\\",
-		"\\___/\\/",
-	},
-	'*': {
-		"      ",
-		" _\\/_",
-		" /  \\",
-		"      ",
-	},
-	'+': {
-		"     ",
-		"  _|_",
-		" |_ _|",
-		"   |  ",
-	},
-	'=': {
-		"      ",
-		" ____ ",
-		"|____|",
-		"|____|",
-	},
-	'<': {
-		"  __",
-		" / /",
-		" \\_\\",
-		"    ",
-	},
-	'>': {
-		"__  ",
-		"\\ \\ ",
-		"/_/ ",
-		"    ",
-	},
-	'|': {
-		" _ ",
-		"| |",
-		"| |",
-		"|_|",
-	},
-	'\\': {
-		"__    ",
-		"\\ \\   ",
-		" \\ \\  ",
-		"  \\_\\ ",
-	},
-	'`': {
-		" _ ",
-		"( )",
-		" \\|",
-		"   ",
-	},
-	'~': {
-		" /\\/|",
-		"|/\\/ ",
-		"     ",
-		"     ",
-	},
-}
-
-// FontHeight returns the number of lines in the small font.
-func FontHeight() int {
-	return 4
-}
@@ -0,0 +1,203 @@
+// Package figlet provides ASCII art text rendering with FIGlet fonts.
+package figlet
+
+import "strings"
+
+// Horizontal layout bits of a font's full layout parameter. Bits 1-32 are
+// the controlled smushing rules.
+const (
+	smushEqual     = 1   // Rule 1: equal characters
+	smushLowline   = 2   // Rule 2: underscores give way to border characters
+	smushHierarchy = 4   // Rule 3: hierarchy of |, /\, [], {}, (), <>
+	smushPair      = 8   // Rule 4: opposite brackets become |
+	smushBigX      = 16  // Rule 5: /\ becomes |, \/ becomes Y, >< becomes X
+	smushHardblank = 32  // Rule 6: two hardblanks become one
+	layoutKerning  = 64  // Letters are moved together until they touch
+	layoutSmushing = 128 // Letters overlap by one column where the rules allow
+)
+
+// layout appends glyphs to the output rows the way FIGlet does, overlapping
+// each new glyph with the previous output as far as the font's layout mode
+// allows.
+type layout struct {
+	font      *Font
+	mode      int
+	rows      [][]rune
+	owners    [][]int // Index of the input character behind each cell
+	prevWidth int     // Width of the previous glyph
+	currWidth int     // Width of the glyph being added
+}
+
+// add appends the glyph of the index-th input character to the output.
+func (l *layout) add(glyph [][]rune, index int) {
+	l.prevWidth, l.currWidth = l.currWidth, len(glyph[0])
+	amount := l.smushAmount(glyph)
+
+	outLen := len(l.rows[0])
+	for row := range l.rows {
+		line, owner := l.rows[row], l.owners[row]
+		for k := 0; k < amount; k++ {
+			column := max(outLen-amount+k, 0)
+			rch := at(glyph[row], k)
+			smushed := l.smush(at(line, column), rch)
+			if column < len(line) {
+				line[column] = smushed
+				if smushed == rch && rch != ' ' {
+					owner[column] = index
+				}
+			} else if smushed != 0 {
+				line = append(line, smushed)
+				owner = append(owner, index)
+			}
+		}
+		// A zero character ends the line, as in FIGlet's C strings
+		for i, c := range line {
+			if c == 0 {
+				line, owner = line[:i], owner[:i]
+				break
+			}
+		}
+		for _, c := range glyph[row][min(amount, len(glyph[row])):] {
+			line = append(line, c)
+			owner = append(owner, index)
+		}
+		l.rows[row], l.owners[row] = line, owner
+	}
+}
+
+// smushAmount returns how many columns the glyph can overlap the output.
+func (l *layout) smushAmount(glyph [][]rune) int {
+	if l.mode&(layoutSmushing|layoutKerning) == 0 {
+		return 0
+	}
+
+	outLen := len(l.rows[0])
+	maxSmush := l.currWidth
+	for row, line := range l.rows {
+		// Last visible character of the output row
+		lineBound := len(line)
+		ch1 := at(line, lineBound)
+		for lineBound > 0 && (ch1 == 0 || ch1 == ' ') {
+			lineBound--
+			ch1 = at(line, lineBound)
+		}
+
+		// First visible character of the glyph row
+		charBound := 0
+		ch2 := at(glyph[row], charBound)
+		for ch2 == ' ' {
+			charBound++
+			ch2 = at(glyph[row], charBound)
+		}
+
+		amount := charBound + outLen - 1 - lineBound
+		if ch1 == 0 || ch1 == ' ' {
+			amount++
+		} else if ch2 != 0 && l.smush(ch1, ch2) != 0 {
+			amount++
+		}
+		maxSmush = min(maxSmush, amount)
+	}
+	return maxSmush
+}
+
+// smush returns the character that results from overlapping lch and rch,
+// or 0 when they cannot be smushed.
+func (l *layout) smush(lch, rch rune) rune {
+	if lch == ' ' {
+		return rch
+	}
+	if rch == ' ' {
+		return lch
+	}
+
+	// Never overlap glyphs one column wide or less
+	if l.prevWidth < 2 || l.currWidth < 2 {
+		return 0
+	}
+	if l.mode&layoutSmushing == 0 {
+		return 0 // Kerning
+	}
+
+	hardblank := l.font.Hardblank
+	if l.mode&63 == 0 {
+		// Universal smushing: visible characters win, then the later one
+		switch {
+		case lch == hardblank:
+			return rch
+		case rch == hardblank:
+			return lch
+		}
+		return rch
+	}
+
+	if l.mode&smushHardblank != 0 && lch == hardblank && rch == hardblank {
+		return lch
+	}
+	if lch == hardblank || rch == hardblank {
+		return 0
+	}
+
+	if l.mode&smushEqual != 0 && lch == rch {
+		return lch
+	}
+
+	if l.mode&smushLowline != 0 {
+		if lch == '_' && in("|/\\[]{}()<>", rch) {
+			return rch
+		}
+		if rch == '_' && in("|/\\[]{}()<>", lch) {
+			return lch
+		}
+	}
+
+	if l.mode&smushHierarchy != 0 {
+		for _, class := range []struct{ upper, lower string }{
+			{"|", "/\\[]{}()<>"},
+			{"/\\", "[]{}()<>"},
+			{"[]", "{}()<>"},
+			{"{}", "()<>"},
+			{"()", "<>"},
+		} {
+			if in(class.upper, lch) && in(class.lower, rch) {
+				return rch
+			}
+			if in(class.upper, rch) && in(class.lower, lch) {
+				return lch
+			}
+		}
+	}
+
+	if l.mode&smushPair != 0 {
+		switch string([]rune{lch, rch}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|'
+		}
+	}
+
+	if l.mode&smushBigX != 0 {
+		switch {
+		case lch == '/' && rch == '\\':
+			return '|'
+		case lch == '\\' && rch == '/':
+			return 'Y'
+		case lch == '>' && rch == '<':
+			return 'X'
+		}
+	}
+
+	return 0
+}
+
+// at returns line[i], or 0 past the end like a C string terminator.
+func at(line []rune, i int) rune {
+	if i < 0 || i >= len(line) {
+		return 0
+	}
+	return line[i]
+}
+
+// in reports whether c is in set, matching 0 like C's strchr does.
+func in(set string, c rune) bool {
+	return c == 0 || strings.ContainsRune(set, c)
+}
@@ -0,0 +1,145 @@
+package figlet
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files from the current output")
+
+// Layout modes in the form FIGlet's -m option and full layout parameter use.
+const (
+	fullWidth = 0
+	kerning   = layoutKerning
+	universal = layoutSmushing
+)
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		font   string
+		layout int // Overrides the font's full layout when >= 0
+		text   string
+	}{
+		// Each font's own layout; mini's 1920 is universal smushing
+		{"small", "small", -1, "Claude Code"},
+		{"standard", "standard", -1, "Hello World"},
+		{"slant", "slant", -1, "Hello World"},
+		{"mini", "mini", -1, "Claude Code"},
+
+		// figlet -W and -k
+		{"standard_full_width", "standard", fullWidth, "Claude Code"},
+		{"standard_kerning", "standard", kerning, "Claude Code"},
+		{"small_full_width", "small", fullWidth, "Claude Code"},
+		{"small_kerning", "small", kerning, "Claude Code"},
+
+		// figlet -m with one controlled rule each, and universal smushing
+		{"standard_rule1", "standard", universal | smushEqual, "Hello ||"},
+		{"standard_rule2", "standard", universal | smushLowline, "_|_/_"},
+		{"standard_rule3", "standard", universal | smushHierarchy, "|/[{(<"},
+		{"standard_rule4", "standard", universal | smushPair, "[]{}()"},
+		{"standard_rule5", "standard", universal | smushBigX, "/\\/><"},
+		{"standard_rule6", "standard", universal | smushHardblank, "T T"},
+		{"standard_universal", "standard", universal, "Claude Code"},
+		{"slant_universal", "slant", universal, "Claude Code"},
+
+		// Characters missing from the font render as its empty character 0
+		{"standard_missing", "standard", -1, "a☃b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			f, err := Lookup(tt.font)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.layout >= 0 {
+				override := *f
+				override.FullLayout = tt.layout
+				f = &override
+			}
+			got := f.Render(tt.text) + "\n" // figlet ends every row with a newline
+
+			path := filepath.Join("testdata", tt.golden+".golden")
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.text, got, want)
+			}
+		})
+	}
+}
+
+func TestSmushRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout int
+		left   string
+		right  string
+		want   string
+	}{
+		{"full width", fullWidth, "x ", " y", "x  y"},
+		{"kerning removes blanks", kerning, "x ", " y", "xy"},
+		{"kerning keeps touching characters", kerning, "x|", "|y", "x||y"},
+		{"rule 1 equal", universal | smushEqual, "x|", "|y", "x|y"},
+		{"rule 1 needs equal", universal | smushEqual, "x|", "/y", "x|/y"},
+		{"rule 2 lowline left", universal | smushLowline, "x_", "|y", "x|y"},
+		{"rule 2 lowline right", universal | smushLowline, "x/", "_y", "x/y"},
+		{"rule 3 hierarchy", universal | smushHierarchy, "x|", "/y", "x/y"},
+		{"rule 3 brackets over parens", universal | smushHierarchy, "x]", "(y", "x(y"},
+		{"rule 3 braces over angles", universal | smushHierarchy, "x>", "}y", "x>y"},
+		{"rule 4 brackets", universal | smushPair, "x[", "]y", "x|y"},
+		{"rule 4 parens", universal | smushPair, "x)", "(y", "x|y"},
+		{"rule 5 slashes", universal | smushBigX, "x/", "\\y", "x|y"},
+		{"rule 5 backslash slash", universal | smushBigX, "x\\", "/y", "xYy"},
+		{"rule 5 angles", universal | smushBigX, "x>", "<y", "xXy"},
+		{"rule 6 hardblanks", universal | smushHardblank, "x$", "$y", "x y"},
+		{"hardblanks need rule 6", universal | smushEqual, "x$", "$y", "x  y"},
+		{"no rule matches", universal | smushLowline, "x|", "|y", "x||y"},
+		{"universal later wins", universal, "xa", "by", "xby"},
+		{"universal over hardblank", universal, "x$", "by", "xby"},
+		{"universal keeps visible", universal, "xa", "$y", "xay"},
+		{"narrow glyphs never smush", universal | smushEqual, "|", "|", "||"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Font{
+				Hardblank:  '$',
+				Height:     1,
+				FullLayout: tt.layout,
+				Chars:      map[rune][]string{'a': {tt.left}, 'b': {tt.right}},
+			}
+			if got := f.Render("ab"); got != tt.want {
+				t.Errorf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingCharacter(t *testing.T) {
+	f := &Font{
+		Hardblank:  '$',
+		Height:     1,
+		FullLayout: universal | smushEqual,
+		Chars:      map[rune][]string{'a': {"x|"}, 'b': {"|y"}},
+	}
+	// An empty glyph stops the next one from smushing, as in FIGlet
+	if got, want := f.Render("a?b"), "x||y"; got != want {
+		t.Errorf("Render without character 0 = %q, want %q", got, want)
+	}
+
+	f.Chars[0] = []string{"<>"}
+	if got, want := f.Render("a?b"), "x|<>|y"; got != want {
+		t.Errorf("Render with character 0 = %q, want %q", got, want)
+	}
+}
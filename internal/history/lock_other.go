@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package history
+
+import "os"
+
+// lockFile is a no-op where flock is unavailable; concurrent runs are then
+// not serialized.
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op where flock is unavailable.
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,435 @@
+// Package history persists usage aggregates so reports outlive Claude Code's session files.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// File names within the history directory.
+const (
+	SessionsFile = "sessions.jsonl"
+	HoursFile    = "hours.jsonl"
+	LockFile     = "history.lock" // Advisory lock held while writing
+)
+
+// SessionRecord is the stored aggregate for a single session.
+type SessionRecord struct {
+	Key             string    `json:"key"`
+	SessionID       string    `json:"session_id"`
+	Project         string    `json:"project"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationHours   float64   `json:"duration_hours"`
+	Prompts         int       `json:"prompts"`
+	SonnetResponses int       `json:"sonnet_responses,omitempty"`
+	OpusResponses   int       `json:"opus_responses,omitempty"`
+
+	Models    map[string]int      `json:"models,omitempty"` // Responses per model ID
+	Tokens    claude.TokenUsage   `json:"tokens"`
+	LimitHits []claude.LimitEvent `json:"limit_hits,omitempty"`
+}
+
+// HourRecord is the stored aggregate for a single session within one clock hour.
+type HourRecord struct {
+	Key             string    `json:"key"` // Owning session key
+	Project         string    `json:"project"`
+	Hour            time.Time `json:"hour"`
+	Prompts         int       `json:"prompts,omitempty"`
+	SonnetResponses int       `json:"sonnet_responses,omitempty"`
+	OpusResponses   int       `json:"opus_responses,omitempty"`
+	ActiveHours     float64   `json:"active_hours,omitempty"`
+
+	Models map[string]int    `json:"models,omitempty"` // Responses per model ID
+	Tokens claude.TokenUsage `json:"tokens"`
+}
+
+// Retention controls how long records are kept when compacting.
+// A zero duration keeps records forever.
+type Retention struct {
+	Sessions time.Duration
+	Hours    time.Duration
+}
+
+type hourKey struct {
+	key  string
+	hour int64
+}
+
+// Store is an append-only on-disk history of session and hourly aggregates.
+// Newer records for the same key supersede older ones; Compact rewrites the
+// files keeping only the latest record for each key. Writes hold an advisory
+// lock so concurrent runs neither interleave appends nor lose them to a
+// compaction.
+type Store struct {
+	dir      string
+	sessions map[string]SessionRecord
+	hours    map[hourKey]HourRecord
+	stale    int // Superseded lines still on disk
+}
+
+// DefaultDir returns $XDG_DATA_HOME/vibe-monitor, falling back to ~/.local/share/vibe-monitor.
+func DefaultDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "vibe-monitor")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "vibe-monitor")
+}
+
+// SessionKey returns the key identifying a session across runs. It uses the
+// raw Claude directory name, which is stable even if project resolution changes.
+func SessionKey(session *claude.SessionData) string {
+	return session.ProjectDir + "/" + session.SessionID
+}
+
+// Open loads the store in dir, creating the directory if needed.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating history dir: %w", err)
+	}
+
+	s := &Store{dir: dir}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load replaces the records in memory with those on disk.
+func (s *Store) load() error {
+	s.sessions = make(map[string]SessionRecord)
+	s.hours = make(map[hourKey]HourRecord)
+	s.stale = 0
+
+	err := readLines(filepath.Join(s.dir, SessionsFile), func(line []byte) {
+		var rec SessionRecord
+		if json.Unmarshal(line, &rec) != nil || rec.Key == "" {
+			return
+		}
+		if _, ok := s.sessions[rec.Key]; ok {
+			s.stale++
+		}
+		s.sessions[rec.Key] = rec
+	})
+	if err != nil {
+		return err
+	}
+
+	return readLines(filepath.Join(s.dir, HoursFile), func(line []byte) {
+		var rec HourRecord
+		if json.Unmarshal(line, &rec) != nil || rec.Key == "" {
+			return
+		}
+		k := hourKey{rec.Key, rec.Hour.Unix()}
+		if _, ok := s.hours[k]; ok {
+			s.stale++
+		}
+		s.hours[k] = rec
+	})
+}
+
+// lock takes the store's cross-process write lock and returns the function
+// that releases it.
+func (s *Store) lock() (func(), error) {
+	file, err := os.OpenFile(filepath.Join(s.dir, LockFile), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening history lock: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("locking history: %w", err)
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// NewMemory returns a store that is never written to disk, for use when
+// history recording is disabled.
+func NewMemory() *Store {
+	return &Store{
+		sessions: make(map[string]SessionRecord),
+		hours:    make(map[hourKey]HourRecord),
+	}
+}
+
+// Dir returns the directory backing the store, or "" for in-memory stores.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Sync appends records for sessions that are new or have changed since they
+// were last stored. It returns the number of sessions written.
+func (s *Store) Sync(sessions []*claude.SessionData) (int, error) {
+	var sessionBuf, hourBuf bytes.Buffer
+	written := 0
+
+	for _, session := range sessions {
+		key := SessionKey(session)
+		rec := SessionRecord{
+			Key:             key,
+			SessionID:       session.SessionID,
+			Project:         session.Project,
+			Start:           session.StartTime.UTC(),
+			End:             session.EndTime.UTC(),
+			DurationHours:   session.DurationHours,
+			Prompts:         session.PromptCount,
+			SonnetResponses: session.SonnetResponses,
+			OpusResponses:   session.OpusResponses,
+			Models:          session.Models,
+			Tokens:          session.Tokens,
+			LimitHits:       session.LimitEvents,
+		}
+
+		changed, err := s.putSession(rec, &sessionBuf)
+		if err != nil {
+			return 0, err
+		}
+		if !changed {
+			continue
+		}
+		written++
+
+		for _, h := range session.Hourly {
+			hrec := HourRecord{
+				Key:             key,
+				Project:         session.Project,
+				Hour:            h.Hour.UTC(),
+				Prompts:         h.Prompts,
+				SonnetResponses: h.SonnetResponses,
+				OpusResponses:   h.OpusResponses,
+				ActiveHours:     h.ActiveHours,
+				Models:          h.Models,
+				Tokens:          h.Tokens,
+			}
+			if _, err := s.putHour(hrec, &hourBuf); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if s.dir == "" || written == 0 {
+		return written, nil
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	if err := appendFile(filepath.Join(s.dir, SessionsFile), sessionBuf.Bytes()); err != nil {
+		return 0, err
+	}
+	if err := appendFile(filepath.Join(s.dir, HoursFile), hourBuf.Bytes()); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// putSession records rec in memory and buf if it differs from the stored copy.
+func (s *Store) putSession(rec SessionRecord, buf *bytes.Buffer) (bool, error) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return false, err
+	}
+	if old, ok := s.sessions[rec.Key]; ok {
+		if prev, err := json.Marshal(old); err == nil && bytes.Equal(prev, line) {
+			return false, nil
+		}
+		s.stale++
+	}
+	s.sessions[rec.Key] = rec
+	buf.Write(line)
+	buf.WriteByte('\n')
+	return true, nil
+}
+
+// putHour records rec in memory and buf if it differs from the stored copy.
+func (s *Store) putHour(rec HourRecord, buf *bytes.Buffer) (bool, error) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return false, err
+	}
+	k := hourKey{rec.Key, rec.Hour.Unix()}
+	if old, ok := s.hours[k]; ok {
+		if prev, err := json.Marshal(old); err == nil && bytes.Equal(prev, line) {
+			return false, nil
+		}
+		s.stale++
+	}
+	s.hours[k] = rec
+	buf.Write(line)
+	buf.WriteByte('\n')
+	return true, nil
+}
+
+// Sessions returns all stored sessions ordered by start time.
+func (s *Store) Sessions() []SessionRecord {
+	records := make([]SessionRecord, 0, len(s.sessions))
+	for _, rec := range s.sessions {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Start.Equal(records[j].Start) {
+			return records[i].Key < records[j].Key
+		}
+		return records[i].Start.Before(records[j].Start)
+	})
+	return records
+}
+
+// Session returns the stored record for key.
+func (s *Store) Session(key string) (SessionRecord, bool) {
+	rec, ok := s.sessions[key]
+	return rec, ok
+}
+
+// Hours returns stored hourly records within [from, to), ordered by hour.
+// A zero bound is treated as open.
+func (s *Store) Hours(from, to time.Time) []HourRecord {
+	var records []HourRecord
+	for _, rec := range s.hours {
+		if !from.IsZero() && rec.Hour.Before(from.Truncate(time.Hour)) {
+			continue
+		}
+		if !to.IsZero() && !rec.Hour.Before(to) {
+			continue
+		}
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Hour.Equal(records[j].Hour) {
+			return records[i].Key < records[j].Key
+		}
+		return records[i].Hour.Before(records[j].Hour)
+	})
+	return records
+}
+
+// Len returns the number of live session and hourly records.
+func (s *Store) Len() int {
+	return len(s.sessions) + len(s.hours)
+}
+
+// Stale returns the number of superseded records still on disk.
+func (s *Store) Stale() int {
+	return s.stale
+}
+
+// Compact rewrites the store keeping only the latest record for each key and
+// dropping records older than the retention policy allows. The files are
+// reread under the lock first, so records other runs appended since Open
+// are kept.
+func (s *Store) Compact(r Retention, now time.Time) error {
+	if s.dir != "" {
+		unlock, err := s.lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if err := s.load(); err != nil {
+			return err
+		}
+	}
+
+	for key, rec := range s.sessions {
+		if r.Sessions > 0 && rec.End.Before(now.Add(-r.Sessions)) {
+			delete(s.sessions, key)
+		}
+	}
+	for k, rec := range s.hours {
+		if r.Hours > 0 && rec.Hour.Before(now.Add(-r.Hours)) {
+			delete(s.hours, k)
+		}
+	}
+
+	if s.dir == "" {
+		s.stale = 0
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, rec := range s.Sessions() {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := replaceFile(filepath.Join(s.dir, SessionsFile), buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	for _, rec := range s.Hours(time.Time{}, time.Time{}) {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := replaceFile(filepath.Join(s.dir, HoursFile), buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.stale = 0
+	return nil
+}
+
+// readLines calls fn for each non-empty line of path. A missing file is not an error.
+func readLines(path string, fn func(line []byte)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); len(line) > 0 {
+			fn(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// appendFile appends data to path in a single write.
+func appendFile(path string, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// replaceFile atomically replaces path with data.
+func replaceFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,190 @@
+package history
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+var base = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// session builds a session of the given prompts starting hoursAgo before
+// base and lasting one hour.
+func session(id string, prompts int, hoursAgo float64) *claude.SessionData {
+	start := base.Add(-time.Duration(hoursAgo * float64(time.Hour)))
+	return &claude.SessionData{
+		SessionID:     id,
+		ProjectDir:    "-home-u-app",
+		Project:       "/home/u/app",
+		StartTime:     start,
+		EndTime:       start.Add(time.Hour),
+		DurationHours: 1,
+		PromptCount:   prompts,
+		Models:        map[string]int{"claude-sonnet-4-5": prompts},
+		Hourly: []claude.HourlyUsage{{
+			Hour:        start.Truncate(time.Hour),
+			Prompts:     prompts,
+			ActiveHours: 1,
+		}},
+	}
+}
+
+// lines returns the number of lines in a store file.
+func lines(t *testing.T, dir, name string) int {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       []*claude.SessionData
+		second      []*claude.SessionData
+		wantWritten int
+		wantLines   int // Session lines on disk after both syncs
+		wantStale   int // Superseded session and hour lines
+	}{
+		{"new sessions", nil, []*claude.SessionData{session("a", 1, 2), session("b", 2, 1)}, 2, 2, 0},
+		{"unchanged session", []*claude.SessionData{session("a", 1, 2)}, []*claude.SessionData{session("a", 1, 2)}, 0, 1, 0},
+		{"changed session", []*claude.SessionData{session("a", 1, 2)}, []*claude.SessionData{session("a", 5, 2)}, 1, 2, 2},
+		{"only changed of several", []*claude.SessionData{session("a", 1, 2), session("b", 2, 1)},
+			[]*claude.SessionData{session("a", 1, 2), session("b", 3, 1)}, 1, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := Open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Sync(tt.first); err != nil {
+				t.Fatal(err)
+			}
+			written, err := s.Sync(tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if written != tt.wantWritten {
+				t.Errorf("Sync wrote %d sessions, want %d", written, tt.wantWritten)
+			}
+			if got := lines(t, dir, SessionsFile); got != tt.wantLines {
+				t.Errorf("%s has %d lines, want %d", SessionsFile, got, tt.wantLines)
+			}
+			if s.Stale() != tt.wantStale {
+				t.Errorf("Stale() = %d, want %d", s.Stale(), tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sync([]*claude.SessionData{session("a", 1, 2), session("b", 2, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sync([]*claude.SessionData{session("a", 4, 2)}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Len() != s.Len() || reloaded.Stale() != s.Stale() {
+		t.Errorf("reloaded Len %d Stale %d, want %d and %d", reloaded.Len(), reloaded.Stale(), s.Len(), s.Stale())
+	}
+	key := SessionKey(session("a", 0, 0))
+	if rec, ok := reloaded.Session(key); !ok || rec.Prompts != 4 {
+		t.Errorf("reloaded session a = %+v, want the latest record with 4 prompts", rec)
+	}
+}
+
+func TestCompactRetention(t *testing.T) {
+	tests := []struct {
+		name         string
+		retention    Retention
+		wantSessions int
+		wantHours    int
+	}{
+		{"keep everything", Retention{}, 3, 3},
+		{"drop old sessions", Retention{Sessions: 48 * time.Hour}, 2, 3},
+		{"drop old hours", Retention{Hours: 48 * time.Hour}, 3, 2},
+		{"drop both", Retention{Sessions: 12 * time.Hour, Hours: 12 * time.Hour}, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := Open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sessions := []*claude.SessionData{session("old", 1, 100), session("week", 1, 30), session("new", 1, 2)}
+			if _, err := s.Sync(sessions); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Sync([]*claude.SessionData{session("new", 2, 2)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Compact(tt.retention, base); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := len(s.Sessions()); got != tt.wantSessions {
+				t.Errorf("%d sessions in memory, want %d", got, tt.wantSessions)
+			}
+			if got := lines(t, dir, SessionsFile); got != tt.wantSessions {
+				t.Errorf("%s has %d lines, want %d", SessionsFile, got, tt.wantSessions)
+			}
+			if got := lines(t, dir, HoursFile); got != tt.wantHours {
+				t.Errorf("%s has %d lines, want %d", HoursFile, got, tt.wantHours)
+			}
+			if s.Stale() != 0 {
+				t.Errorf("Stale() = %d after Compact, want 0", s.Stale())
+			}
+		})
+	}
+}
+
+func TestCompactKeepsConcurrentAppends(t *testing.T) {
+	dir := t.TempDir()
+	first, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := first.Sync([]*claude.SessionData{session("a", 1, 2)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Sync([]*claude.SessionData{session("b", 1, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Compact(Retention{}, base); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.Sessions()); got != 2 {
+		t.Errorf("%d sessions after compaction, want both runs' 2", got)
+	}
+}
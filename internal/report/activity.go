@@ -0,0 +1,136 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// Chart selects an activity series.
+type Chart string
+
+// Activity charts.
+const (
+	ChartHourly Chart = "hourly" // Prompts per hour over the last 24 hours
+	ChartDaily  Chart = "daily"  // Active hours per day over the last 4 weeks
+)
+
+// Charts lists the activity charts in display order.
+var Charts = []Chart{ChartHourly, ChartDaily}
+
+// ParseChart validates a chart name.
+func ParseChart(s string) (Chart, error) {
+	switch c := Chart(s); c {
+	case ChartHourly, ChartDaily:
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid chart %q (want hourly or daily)", s)
+}
+
+// Series is activity in evenly spaced buckets, oldest first, split by model
+// family.
+type Series struct {
+	Chart    Chart    `json:"chart"`
+	Title    string   `json:"title"`
+	Unit     string   `json:"unit"`     // "prompts" or "h"
+	Families []string `json:"families"` // Families with activity, heaviest first
+	Points   []Point  `json:"points"`
+}
+
+// Point is the activity of one bucket.
+type Point struct {
+	Start    time.Time          `json:"start"`
+	Total    float64            `json:"total"`
+	Families map[string]float64 `json:"families,omitempty"`
+}
+
+// Max returns the largest bucket total.
+func (s Series) Max() float64 {
+	m := 0.0
+	for _, p := range s.Points {
+		m = max(m, p.Total)
+	}
+	return m
+}
+
+// Totals returns the activity of each family over the whole series.
+func (s Series) Totals() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, p := range s.Points {
+		for family, v := range p.Families {
+			totals[family] += v
+		}
+	}
+	return totals
+}
+
+// Activity builds a chart's series from the store's hourly records, ending
+// with the bucket containing now.
+func Activity(store *history.Store, chart Chart, now time.Time, models claude.ModelTable) Series {
+	if chart == ChartDaily {
+		end := startOfDay(now).AddDate(0, 0, 1)
+		s := Series{Chart: chart, Title: "Active hours per day, last 4 weeks", Unit: "h"}
+		return s.fill(store, models, end.AddDate(0, 0, -28), end, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+			func(rec history.HourRecord) float64 { return rec.ActiveHours })
+	}
+
+	end := now.Truncate(time.Hour).Add(time.Hour)
+	s := Series{Chart: ChartHourly, Title: "Prompts per hour, last 24 hours", Unit: "prompts"}
+	return s.fill(store, models, end.Add(-24*time.Hour), end, func(t time.Time) time.Time { return t.Add(time.Hour) },
+		func(rec history.HourRecord) float64 { return float64(rec.Prompts) })
+}
+
+// fill creates the buckets of [from, to), stepping with next, and adds each
+// hourly record's value to its bucket, apportioned between model families by
+// the hour's response share.
+func (s Series) fill(store *history.Store, models claude.ModelTable, from, to time.Time, next func(time.Time) time.Time, value func(history.HourRecord) float64) Series {
+	for t := from; t.Before(to); t = next(t) {
+		s.Points = append(s.Points, Point{Start: t, Families: make(map[string]float64)})
+	}
+
+	for _, rec := range store.Hours(from, to) {
+		v := value(rec)
+		if v == 0 {
+			continue
+		}
+		i := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].Start.After(rec.Hour) }) - 1
+		if i < 0 {
+			continue
+		}
+		p := &s.Points[i]
+		p.Total += v
+		for family, share := range familyShares(store, rec, models) {
+			p.Families[family] += v * share
+		}
+	}
+
+	totals := s.Totals()
+	for family := range totals {
+		s.Families = append(s.Families, family)
+	}
+	sort.Slice(s.Families, func(i, j int) bool {
+		a, b := s.Families[i], s.Families[j]
+		if totals[a] != totals[b] {
+			return totals[a] > totals[b]
+		}
+		return a < b
+	})
+	return s
+}
+
+// familyShares splits an hourly record between model families by its
+// responses, or the owning session's when the hour has none, defaulting to
+// Sonnet like Tracker does.
+func familyShares(store *history.Store, rec history.HourRecord, models claude.ModelTable) map[string]float64 {
+	shares := make(map[string]float64)
+	for id, share := range claude.SplitByModel(1, hourModels(store, rec)) {
+		shares[models.Normalize(id).Family] += share
+	}
+	if len(shares) == 0 {
+		shares[claude.FamilySonnet] = 1
+	}
+	return shares
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// Metric selects what a heatmap measures.
+type Metric string
+
+// Heatmap metrics.
+const (
+	MetricHours   Metric = "hours"
+	MetricPrompts Metric = "prompts"
+)
+
+// ParseMetric validates a heatmap metric name.
+func ParseMetric(s string) (Metric, error) {
+	switch m := Metric(s); m {
+	case MetricHours, MetricPrompts:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid metric %q (want hours or prompts)", s)
+}
+
+// HeatmapWeeks returns the number of calendar weeks shown for a period
+// ("year" or "quarter").
+func HeatmapWeeks(period string) (int, error) {
+	switch period {
+	case "year":
+		return 53, nil
+	case "quarter":
+		return 13, nil
+	}
+	return 0, fmt.Errorf("invalid period %q (want year or quarter)", period)
+}
+
+// Heatmap holds daily activity over whole calendar weeks, Monday first,
+// ending with the current week.
+type Heatmap struct {
+	Metric Metric    `json:"metric"`
+	From   time.Time `json:"from"` // Monday of the first week
+	To     time.Time `json:"to"`   // Start of tomorrow; later days are not shown
+	Days   []Day     `json:"days"`
+}
+
+// Day is the activity of one calendar day.
+type Day struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// BuildHeatmap totals the store's hourly records per local day for the given
+// number of weeks up to now.
+func BuildHeatmap(store *history.Store, metric Metric, weeks int, now time.Time) *Heatmap {
+	h := &Heatmap{
+		Metric: metric,
+		From:   claude.WeekStart(now).AddDate(0, 0, -7*(weeks-1)),
+		To:     startOfDay(now).AddDate(0, 0, 1),
+	}
+	index := make(map[time.Time]int)
+	for day := h.From; day.Before(h.To); day = day.AddDate(0, 0, 1) {
+		index[day] = len(h.Days)
+		h.Days = append(h.Days, Day{Date: day})
+	}
+
+	for _, rec := range store.Hours(h.From, h.To) {
+		i, ok := index[startOfDay(rec.Hour.In(now.Location()))]
+		if !ok {
+			continue
+		}
+		if metric == MetricPrompts {
+			h.Days[i].Value += float64(rec.Prompts)
+		} else {
+			h.Days[i].Value += rec.ActiveHours
+		}
+	}
+	return h
+}
+
+// Total returns the sum over all days.
+func (h *Heatmap) Total() float64 {
+	total := 0.0
+	for _, d := range h.Days {
+		total += d.Value
+	}
+	return total
+}
+
+// Busiest returns the day with the most activity, and false when there was
+// none.
+func (h *Heatmap) Busiest() (Day, bool) {
+	var best Day
+	for _, d := range h.Days {
+		if d.Value > best.Value {
+			best = d
+		}
+	}
+	return best, best.Value > 0
+}
+
+// ActiveDays returns the number of days with any activity.
+func (h *Heatmap) ActiveDays() int {
+	n := 0
+	for _, d := range h.Days {
+		if d.Value > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+)
+
+// ParseRange resolves --since and --until values into a [from, to) range.
+//
+// Both accept dates (2006-01-02), RFC 3339 timestamps, relative offsets
+// (12h, 7d, 2w, 3m) and named periods (today, yesterday, this-week,
+// last-week, this-month, last-month). A named period given as --since with
+// no --until covers just that period; otherwise an empty --until means now.
+func ParseRange(since, until string, now time.Time) (time.Time, time.Time, error) {
+	from, periodEnd, err := parsePoint(since, now, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("--since: %w", err)
+	}
+
+	to := now
+	if until != "" {
+		if to, _, err = parsePoint(until, now, true); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("--until: %w", err)
+		}
+	} else if !periodEnd.IsZero() {
+		to = periodEnd
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("empty range %s → %s",
+			from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	return from, to, nil
+}
+
+// parsePoint resolves a single range bound. For named periods it also returns
+// the period's end so a lone --since can imply --until.
+func parsePoint(s string, now time.Time, isEnd bool) (time.Time, time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "now" {
+		return now, time.Time{}, nil
+	}
+
+	if start, end, ok := namedPeriod(s, now); ok {
+		if isEnd {
+			return end, time.Time{}, nil
+		}
+		return start, end, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		if isEnd {
+			return t.AddDate(0, 0, 1), time.Time{}, nil // Dates are inclusive
+		}
+		return t, time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t.In(now.Location()), time.Time{}, nil
+	}
+
+	if len(s) >= 2 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err == nil && n >= 0 {
+			switch s[len(s)-1] {
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), time.Time{}, nil
+			case 'd':
+				return startOfDay(now).AddDate(0, 0, -n+1), time.Time{}, nil
+			case 'w':
+				return startOfDay(now).AddDate(0, 0, -7*n+1), time.Time{}, nil
+			case 'm':
+				return startOfDay(now).AddDate(0, -n, 1), time.Time{}, nil
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("unrecognised time %q", s)
+}
+
+// namedPeriod resolves period names to their [start, end) range.
+func namedPeriod(name string, now time.Time) (time.Time, time.Time, bool) {
+	today := startOfDay(now)
+	week := claude.WeekStart(now)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	switch name {
+	case "today":
+		return today, today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, true
+	case "this-week":
+		return week, week.AddDate(0, 0, 7), true
+	case "last-week":
+		return week.AddDate(0, 0, -7), week, true
+	case "this-month":
+		return month, month.AddDate(0, 1, 0), true
+	case "last-month":
+		return month.AddDate(0, -1, 0), month, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// startOfDay returns midnight of t's day in t's location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
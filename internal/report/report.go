@@ -0,0 +1,336 @@
+// Package report aggregates stored usage history into date-range reports.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/injaneity/vibe-monitor/internal/claude"
+	"github.com/injaneity/vibe-monitor/internal/history"
+)
+
+// GroupBy selects the bucket size of a report.
+type GroupBy string
+
+// Supported report groupings.
+const (
+	GroupDay   GroupBy = "day"
+	GroupWeek  GroupBy = "week"
+	GroupMonth GroupBy = "month"
+)
+
+// ParseGroupBy validates a --group-by value.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(s); g {
+	case GroupDay, GroupWeek, GroupMonth:
+		return g, nil
+	}
+	return "", fmt.Errorf("invalid group %q (want day, week or month)", s)
+}
+
+// Bucket holds usage totals for one reporting period.
+type Bucket struct {
+	Start       time.Time         `json:"start"`
+	End         time.Time         `json:"end"`
+	Prompts     int               `json:"prompts"`
+	ActiveHours float64           `json:"active_hours"`
+	SonnetHours float64           `json:"sonnet_hours"`
+	OpusHours   float64           `json:"opus_hours"`
+	Tokens      claude.TokenUsage `json:"tokens"`
+	Sessions    int               `json:"sessions"`
+
+	// Hours with any session active, so parallel sessions count once
+	UnionHours       float64 `json:"union_hours"`
+	UnionSonnetHours float64 `json:"union_sonnet_hours"`
+	UnionOpusHours   float64 `json:"union_opus_hours"`
+
+	// Sonnet + Opus limit for the bucket under the limits then in force,
+	// pro-rated from weekly limits by day
+	LimitHours   float64 `json:"limit_hours"`
+	LimitPercent float64 `json:"limit_percent"`
+}
+
+// Report is a date-range usage breakdown.
+type Report struct {
+	Tier    string    `json:"tier"`
+	Hours   string    `json:"hours_mode"` // Hours counted against limits (summed or union)
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	GroupBy GroupBy   `json:"group_by"`
+	Buckets []Bucket  `json:"buckets"`
+	Total   Bucket    `json:"total"`
+
+	Models []claude.ModelUsage `json:"models,omitempty"` // Set when a model breakdown is requested
+}
+
+// Build aggregates the store's hourly records within [from, to) into buckets.
+// Every bucket in the range is present, including empty ones. Each bucket is
+// measured against the tier limits that were in force at the time, counting
+// hours by the hours mode (summed when empty), with model IDs classified by
+// models.
+func Build(store *history.Store, from, to time.Time, group GroupBy, tier, hours string, models claude.ModelTable) *Report {
+	r := &Report{
+		Tier:    tier,
+		Hours:   claude.HoursModeOrDefault(hours),
+		From:    from,
+		To:      to,
+		GroupBy: group,
+		Total:   Bucket{Start: from, End: to},
+	}
+
+	for start := BucketStart(from, group); start.Before(to); start = nextBucket(start, group) {
+		r.Buckets = append(r.Buckets, Bucket{Start: start, End: nextBucket(start, group)})
+	}
+	if len(r.Buckets) == 0 {
+		return r
+	}
+
+	bucketSessions := make([]map[string]bool, len(r.Buckets))
+	totalSessions := make(map[string]bool)
+	idx := 0
+
+	for _, rec := range store.Hours(from, to) {
+		hour := rec.Hour.In(from.Location())
+		for idx < len(r.Buckets)-1 && !hour.Before(r.Buckets[idx].End) {
+			idx++
+		}
+		b := &r.Buckets[idx]
+
+		sonnet, opus := splitHours(store, rec, models)
+		b.Prompts += rec.Prompts
+		b.ActiveHours += rec.ActiveHours
+		b.SonnetHours += sonnet
+		b.OpusHours += opus
+		b.Tokens.Add(rec.Tokens)
+
+		if bucketSessions[idx] == nil {
+			bucketSessions[idx] = make(map[string]bool)
+		}
+		bucketSessions[idx][rec.Key] = true
+		totalSessions[rec.Key] = true
+	}
+
+	spans := sessionSpans(store, from, to, models)
+	for i := range r.Buckets {
+		b := &r.Buckets[i]
+		b.Sessions = len(bucketSessions[i])
+		start, end := maxTime(b.Start, from), minTime(b.End, to)
+		b.setUnion(spans, start, end)
+		b.setLimit(tier, r.Hours, start, end)
+		r.Total.Prompts += b.Prompts
+		r.Total.ActiveHours += b.ActiveHours
+		r.Total.SonnetHours += b.SonnetHours
+		r.Total.OpusHours += b.OpusHours
+		r.Total.Tokens.Add(b.Tokens)
+	}
+	r.Total.Sessions = len(totalSessions)
+	r.Total.setUnion(spans, from, to)
+	r.Total.setLimit(tier, r.Hours, from, to)
+
+	return r
+}
+
+// setLimit pro-rates the weekly limits in force on each day of [from, to),
+// measuring the bucket's hours counted by mode against them.
+// Buckets with days before the tier's first known limits get no limit, rather
+// than being judged by limits that did not apply yet.
+func (b *Bucket) setLimit(tier, mode string, from, to time.Time) {
+	b.LimitHours, b.LimitPercent = 0, 0
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end := day, day.AddDate(0, 0, 1)
+		if from.After(start) {
+			start = from
+		}
+		if to.Before(end) {
+			end = to
+		}
+		fraction := end.Sub(start).Hours() / 24
+		limits, ok := claude.GetTierLimitsAt(tier, day)
+		if !ok {
+			b.LimitHours = 0
+			return
+		}
+		b.LimitHours += limits.GetTotalWeeklyMax() / 7 * fraction
+	}
+	if b.LimitHours > 0 {
+		hours := b.SonnetHours + b.OpusHours
+		if mode == claude.HoursUnion {
+			hours = b.UnionHours
+		}
+		b.LimitPercent = hours / b.LimitHours * 100
+	}
+}
+
+// sessionSpans returns the active spans of the stored sessions overlapping
+// [from, to).
+func sessionSpans(store *history.Store, from, to time.Time, models claude.ModelTable) []claude.Span {
+	var spans []claude.Span
+	for _, rec := range store.Sessions() {
+		if rec.End.After(from) && rec.Start.Before(to) {
+			spans = append(spans, models.NewSpan(rec.Start, rec.End, sessionModels(rec)))
+		}
+	}
+	return spans
+}
+
+// setUnion sets the union hours of the spans within [from, to).
+func (b *Bucket) setUnion(spans []claude.Span, from, to time.Time) {
+	var clipped []claude.Span
+	for _, s := range spans {
+		if s, ok := s.Clip(from, to); ok {
+			clipped = append(clipped, s)
+		}
+	}
+	b.UnionSonnetHours, b.UnionOpusHours = claude.WeeklyHours(claude.UnionHours(clipped))
+	b.UnionHours = b.UnionSonnetHours + b.UnionOpusHours
+}
+
+// minTime returns the earlier of two times.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of two times.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// splitHours divides an hourly record's active time between models using the
+// hour's responses per model, defaulting to Sonnet like Tracker does.
+func splitHours(store *history.Store, rec history.HourRecord, models claude.ModelTable) (sonnet, opus float64) {
+	return models.SplitWeeklyHours(rec.ActiveHours, hourModels(store, rec))
+}
+
+// hourModels returns an hourly record's responses per model. Hours with no
+// responses of their own inherit the owning session's mix, so the Sonnet and
+// Opus split agrees with the per-model breakdown.
+func hourModels(store *history.Store, rec history.HourRecord) map[string]int {
+	if len(rec.Models) > 0 {
+		return rec.Models
+	}
+	if rec.SonnetResponses > 0 || rec.OpusResponses > 0 {
+		return map[string]int{
+			claude.FamilySonnet: rec.SonnetResponses,
+			claude.FamilyOpus:   rec.OpusResponses,
+		}
+	}
+	if session, ok := store.Session(rec.Key); ok {
+		return sessionModels(session)
+	}
+	return nil
+}
+
+// sessionModels returns a session's responses per model, falling back to the
+// Sonnet and Opus counts of records stored before models were recorded.
+func sessionModels(rec history.SessionRecord) map[string]int {
+	if len(rec.Models) > 0 {
+		return rec.Models
+	}
+	return map[string]int{
+		claude.FamilySonnet: rec.SonnetResponses,
+		claude.FamilyOpus:   rec.OpusResponses,
+	}
+}
+
+// BucketStart returns the start of the bucket containing t.
+func BucketStart(t time.Time, group GroupBy) time.Time {
+	switch group {
+	case GroupWeek:
+		return claude.WeekStart(t)
+	case GroupMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return startOfDay(t)
+	}
+}
+
+// nextBucket returns the start of the bucket following start.
+func nextBucket(start time.Time, group GroupBy) time.Time {
+	switch group {
+	case GroupWeek:
+		return start.AddDate(0, 0, 7)
+	case GroupMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Label returns a short display label for a bucket.
+func (b Bucket) Label(group GroupBy) string {
+	switch group {
+	case GroupWeek:
+		year, week := b.Start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case GroupMonth:
+		return b.Start.Format("2006-01 Jan")
+	default:
+		return b.Start.Format("2006-01-02 Mon")
+	}
+}
+
+// Projects aggregates the store's hourly records within [from, to) per project.
+func Projects(store *history.Store, from, to time.Time, models claude.ModelTable) []claude.ProjectUsage {
+	projects := make(map[string]*claude.ProjectUsage)
+	sessions := make(map[string]map[string]bool)
+
+	for _, rec := range store.Hours(from, to) {
+		name := claude.CanonicalProject(rec.Project)
+		p, ok := projects[name]
+		if !ok {
+			p = &claude.ProjectUsage{Project: name}
+			projects[name] = p
+			sessions[name] = make(map[string]bool)
+		}
+		sonnet, opus := splitHours(store, rec, models)
+		p.Prompts += rec.Prompts
+		p.SonnetHours += sonnet
+		p.OpusHours += opus
+		p.Tokens.Add(rec.Tokens)
+		sessions[name][rec.Key] = true
+	}
+
+	result := make([]claude.ProjectUsage, 0, len(projects))
+	for name, p := range projects {
+		p.Sessions = len(sessions[name])
+		result = append(result, *p)
+	}
+	claude.NameProjects(result)
+	claude.SortProjects(result, claude.SortByHours)
+	return result
+}
+
+// Models aggregates the store's hourly records within [from, to) per model ID,
+// apportioning each hour's active time by that hour's response share and
+// classifying model IDs with table.
+func Models(store *history.Store, from, to time.Time, table claude.ModelTable) []claude.ModelUsage {
+	models := make(map[string]*claude.ModelUsage)
+
+	for _, rec := range store.Hours(from, to) {
+		split := claude.SplitByModel(rec.ActiveHours, hourModels(store, rec))
+		for id, hours := range split {
+			m, ok := models[id]
+			if !ok {
+				m = &claude.ModelUsage{Model: table.Normalize(id)}
+				models[id] = m
+			}
+			m.Hours += hours
+			if len(rec.Models) > 0 {
+				m.Responses += rec.Models[id]
+			}
+		}
+	}
+
+	result := make([]claude.ModelUsage, 0, len(models))
+	for _, m := range models {
+		result = append(result, *m)
+	}
+	claude.SortModels(result)
+	return result
+}